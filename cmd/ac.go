@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var acCmd = &cobra.Command{
+	Use:   "ac",
+	Short: "Track acceptance criteria checkboxes",
+}
+
+var acListCmd = &cobra.Command{
+	Use:   "list <id>",
+	Short: "List acceptance criteria with their numbers",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
+		issue, err := s.ReadIssue(id)
+		if err != nil {
+			return err
+		}
+		items := issue.Checklist()
+
+		if jsonOut {
+			out := acListJSON{
+				SchemaVersion: format.JSONSchemaVersion,
+				ID:            issue.ID,
+				Progress:      format.AcceptanceJSON{Done: issue.ACProgress.Done, Total: issue.ACProgress.Total},
+				Items:         []acItemJSON{},
+			}
+			for i, item := range items {
+				out.Items = append(out.Items, acItemJSON{Text: item.Text, Checked: item.Checked, N: i + 1})
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+
+		if len(items) == 0 {
+			fmt.Printf("%s has no acceptance criteria checkboxes.\n", id)
+			return nil
+		}
+		for i, item := range items {
+			mark := "[ ]"
+			if item.Checked {
+				mark = "[x]"
+			}
+			fmt.Printf("%3d. %s %s\n", i+1, mark, item.Text)
+		}
+		fmt.Printf("\n%s complete\n", issue.ACProgress)
+		return nil
+	},
+}
+
+// acListJSON is the --json output of nd ac list.
+type acListJSON struct {
+	SchemaVersion int                   `json:"schema_version"`
+	ID            string                `json:"id"`
+	Progress      format.AcceptanceJSON `json:"progress"`
+	Items         []acItemJSON          `json:"items"`
+}
+
+type acItemJSON struct {
+	Text    string `json:"text"`
+	Checked bool   `json:"checked"`
+	N       int    `json:"n"` // the number ac check and ac uncheck take
+}
+
+var acCheckCmd = &cobra.Command{
+	Use:   "check <id> <n>",
+	Short: "Mark acceptance criterion n as done",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setACItem(args, true)
+	},
+}
+
+var acUncheckCmd = &cobra.Command{
+	Use:   "uncheck <id> <n>",
+	Short: "Mark acceptance criterion n as not done",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setACItem(args, false)
+	},
+}
+
+// setACItem is the shared RunE body for ac check and ac uncheck.
+func setACItem(args []string, checked bool) error {
+	id := args[0]
+	n, err := strconv.Atoi(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid criterion number %q: %w", args[1], err)
+	}
+
+	s, err := store.Open(resolveVaultDir())
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if id, err = s.ResolveID(id); err != nil {
+		return err
+	}
+
+	item, err := s.SetAcceptanceItem(id, n, checked)
+	if err != nil {
+		return err
+	}
+	if !quiet {
+		mark := "[ ]"
+		if item.Checked {
+			mark = "[x]"
+		}
+		fmt.Printf("%s #%d %s %s\n", id, n, mark, item.Text)
+	}
+	return nil
+}
+
+func init() {
+	acCmd.AddCommand(acListCmd)
+	acCmd.AddCommand(acCheckCmd)
+	acCmd.AddCommand(acUncheckCmd)
+	rootCmd.AddCommand(acCmd)
+}
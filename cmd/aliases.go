@@ -51,7 +51,13 @@ var startCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
-		if err := s.UpdateStatus(id, model.StatusInProgress); err != nil {
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if err := s.UpdateStatus(id, model.StatusInProgress, store.TransitionOptions{Force: force}); err != nil {
 			return err
 		}
 		if !quiet {
@@ -69,6 +75,14 @@ func depRmRunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	defer s.Close()
+
+	if issueID, err = s.ResolveID(issueID); err != nil {
+		return err
+	}
+	if depID, err = s.ResolveID(depID); err != nil {
+		return err
+	}
+
 	if err := s.RemoveDependency(issueID, depID); err != nil {
 		return err
 	}
@@ -86,6 +100,14 @@ func depAddRunE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	defer s.Close()
+
+	if issueID, err = s.ResolveID(issueID); err != nil {
+		return err
+	}
+	if depID, err = s.ResolveID(depID); err != nil {
+		return err
+	}
+
 	if err := s.AddDependency(issueID, depID); err != nil {
 		return err
 	}
@@ -99,5 +121,6 @@ func init() {
 	rootCmd.AddCommand(resolveCmd)
 	rootCmd.AddCommand(unblockCmd)
 	rootCmd.AddCommand(blockCmd)
+	startCmd.Flags().Bool("force", false, "start even when a WIP limit is reached (logged in history)")
 	rootCmd.AddCommand(startCmd)
 }
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/plan"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update an issue tree from a plan file",
+	Long: `Creates the issues a plan file declares, with their parents, blockers,
+follows links, labels, and descriptions, in one locked operation. Items have
+local keys (default: a slug of the title) that other items refer to, and
+references may also name existing issues by ID. The plan is checked before
+anything is written, including for dependency, follows, and parent cycles.
+
+Each issue remembers "<plan>/<key>" in plan_key, so applying the plan again
+changes nothing, and applying an edited plan updates the matching issues.
+Fields an item leaves out are not touched, and links are only added, never
+removed. An item without a key is matched by its title, so give an item you
+may retitle an explicit key; open issues of the plan that no item matches
+any more are listed as orphaned and left alone.
+
+The plan name is --name, else the YAML plan: field, else the file's base
+name. Plans with different names never match each other's issues, and an
+item can refer to another plan's issue as <plan>/<key>. Renaming the plan
+(or the file) makes it create new issues.
+
+Due dates must be absolute (YYYY-MM-DD or RFC3339); relative ones such as
++1w are refused because they would move on every apply.
+
+A YAML plan:
+
+  plan: auth
+  issues:
+    - key: auth
+      title: Auth overhaul
+      type: epic
+      children:
+        - key: schema
+          title: Session schema
+          estimate: 4h
+        - title: Login form
+          blocked_by: [schema]
+          follows: [schema]
+
+The same plan as a markdown outline (.md), where nesting makes parents,
+indented text is the description, and {...} holds the other fields:
+
+  - Auth overhaul {key=auth type=epic}
+    - Session schema {key=schema estimate=4h}
+    - Login form {blocked_by=schema follows=schema}`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		planFormat, _ := cmd.Flags().GetString("format")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		name, _ := cmd.Flags().GetString("name")
+		if file == "" {
+			return usageError{fmt.Errorf("--file is required")}
+		}
+		if planFormat == "" {
+			planFormat = plan.FormatFor(file)
+		}
+
+		var data []byte
+		var err error
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			return fmt.Errorf("read plan: %w", err)
+		}
+		p, err := plan.Parse(data, planFormat)
+		if err != nil {
+			return usageError{err}
+		}
+		if name != "" {
+			p.Name = name
+		} else if p.Name == "" {
+			p.Name = plan.NameFor(file)
+		}
+		if p.Name == "" {
+			return usageError{fmt.Errorf("a plan read from stdin needs a name: use --name, or plan: in a YAML plan")}
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		actions, err := s.Apply(p, store.ApplyOptions{DryRun: dryRun})
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			out := applyJSON{SchemaVersion: format.JSONSchemaVersion, DryRun: dryRun, Actions: []applyActionJSON{}}
+			for _, a := range actions {
+				changes := a.Changes
+				if changes == nil {
+					changes = []string{}
+				}
+				out.Actions = append(out.Actions, applyActionJSON{Key: a.Key, ID: a.ID, Action: a.Action, Changes: changes})
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+		if quiet {
+			return nil
+		}
+
+		verbs := map[string]string{store.ApplyCreate: "Created", store.ApplyUpdate: "Updated", store.ApplyOrphaned: "Orphaned"}
+		if dryRun {
+			verbs = map[string]string{store.ApplyCreate: "Would create", store.ApplyUpdate: "Would update", store.ApplyOrphaned: "Orphaned"}
+		}
+		counts := make(map[string]int)
+		for _, a := range actions {
+			counts[a.Action]++
+			verb, ok := verbs[a.Action]
+			if !ok {
+				continue
+			}
+			line := fmt.Sprintf("%s %s (%s): %s", verb, a.ID, a.Key, a.Title)
+			if a.Action == store.ApplyOrphaned {
+				line += " [no plan item has this key; retitled items need an explicit key]"
+			}
+			if len(a.Changes) > 0 {
+				line += " [" + strings.Join(a.Changes, ", ") + "]"
+			}
+			fmt.Println(line)
+		}
+		summary := "Applied plan"
+		if dryRun {
+			summary = "Dry run"
+		}
+		summary = fmt.Sprintf("%s: %d created, %d updated, %d unchanged", summary,
+			counts[store.ApplyCreate], counts[store.ApplyUpdate], counts[store.ApplyUnchanged])
+		if n := counts[store.ApplyOrphaned]; n > 0 {
+			summary += fmt.Sprintf(", %d orphaned", n)
+		}
+		fmt.Println(summary)
+		return nil
+	},
+}
+
+// applyJSON is the --json output of nd apply.
+type applyJSON struct {
+	SchemaVersion int               `json:"schema_version"`
+	DryRun        bool              `json:"dry_run"`
+	Actions       []applyActionJSON `json:"actions"`
+}
+
+type applyActionJSON struct {
+	Key     string   `json:"key"`
+	ID      string   `json:"id"`
+	Action  string   `json:"action"`  // create, update, unchanged, or orphaned
+	Changes []string `json:"changes"` // for update: fields, and links as "blocked_by +ID"
+}
+
+func init() {
+	applyCmd.Flags().StringP("file", "f", "", "plan file (- for stdin)")
+	applyCmd.Flags().String("format", "", "plan format: yaml or md (default: md for .md files, yaml otherwise)")
+	applyCmd.Flags().String("name", "", "plan name that scopes its keys (default: plan: field, then the file's base name)")
+	applyCmd.Flags().Bool("dry-run", false, "show what would be created and updated without writing")
+	rootCmd.AddCommand(applyCmd)
+}
@@ -20,6 +20,10 @@ var childrenCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		issues, err := s.ListIssues(store.FilterOptions{Parent: id})
 		if err != nil {
 			return err
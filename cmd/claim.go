@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var claimCmd = &cobra.Command{
+	Use:   "claim [id]",
+	Short: "Atomically take an issue and start a lease on it",
+	Long: `Assigns an issue to the actor, moves it to in_progress, and records a lease
+that expires unless renewed with nd heartbeat. Without an ID (or with
+--from-ready), picks the highest-priority ready issue that is open and
+unassigned, oldest first; --label narrows the pick.
+
+The pick and the claim happen under the vault lock, so agents claiming at
+the same time never get the same issue. The actor comes from --as, then the
+ND_ACTOR environment variable, then config created_by.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromReady, _ := cmd.Flags().GetBool("from-ready")
+		label, _ := cmd.Flags().GetString("label")
+		lease, _ := cmd.Flags().GetDuration("lease")
+		if len(args) == 1 && (fromReady || label != "") {
+			return usageError{fmt.Errorf("--from-ready and --label pick an issue; do not pass an ID with them")}
+		}
+		if lease <= 0 {
+			return usageError{fmt.Errorf("--lease must be positive")}
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		opts := store.ClaimOptions{Actor: resolveActor(cmd, s), Lease: lease, Label: label}
+		var claimed *model.Issue
+		if len(args) == 1 {
+			var id string
+			if id, err = s.ResolveID(args[0]); err != nil {
+				return err
+			}
+			claimed, err = s.Claim(id, opts)
+		} else {
+			claimed, err = s.ClaimNext(opts)
+		}
+		if err != nil {
+			return err
+		}
+		if claimed == nil {
+			if jsonOut {
+				// The envelope of nd show --json, with no issue.
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(claimNoneJSON{SchemaVersion: format.JSONSchemaVersion})
+			} else if !quiet {
+				fmt.Println("No ready issue to claim.")
+			}
+			return nil
+		}
+
+		if jsonOut {
+			return format.JSONSingle(os.Stdout, claimed)
+		} else if quiet {
+			fmt.Println(claimed.ID)
+		} else {
+			fmt.Printf("Claimed %s: %s (as %s, lease until %s)\n", claimed.ID, claimed.Title, claimed.Assignee, claimed.LeaseUntil)
+		}
+		return nil
+	},
+}
+
+type claimNoneJSON struct {
+	SchemaVersion int               `json:"schema_version"`
+	Issue         *format.IssueJSON `json:"issue"` // always null
+}
+
+var heartbeatCmd = &cobra.Command{
+	Use:   "heartbeat <id>",
+	Short: "Extend the lease on a claimed issue",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lease, _ := cmd.Flags().GetDuration("lease")
+		if lease <= 0 {
+			return usageError{fmt.Errorf("--lease must be positive")}
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		id, err := s.ResolveID(args[0])
+		if err != nil {
+			return err
+		}
+		issue, err := s.Heartbeat(id, resolveActor(cmd, s), lease)
+		if err != nil {
+			return err
+		}
+		if !quiet {
+			fmt.Printf("Lease on %s extended until %s\n", issue.ID, issue.LeaseUntil)
+		}
+		return nil
+	},
+}
+
+var reapCmd = &cobra.Command{
+	Use:   "reap",
+	Short: "Release issues whose claim lease has expired",
+	Long:  "Returns every issue with an expired lease to open, clears its assignee and lease, and notes the release in History.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		reaped, err := s.ReapLeases(time.Now())
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			// Report the issues as they are now, released.
+			released := make([]*model.Issue, 0, len(reaped))
+			for _, issue := range reaped {
+				if now, err := s.ReadIssue(issue.ID); err == nil {
+					issue = now
+				}
+				released = append(released, issue)
+			}
+			return format.JSON(os.Stdout, released)
+		}
+		if quiet {
+			return nil
+		}
+		if len(reaped) == 0 {
+			fmt.Println("No expired leases.")
+			return nil
+		}
+		for _, issue := range reaped {
+			fmt.Printf("Released %s: %s (held by %s, lease until %s)\n", issue.ID, issue.Title, issue.Assignee, issue.LeaseUntil)
+		}
+		return nil
+	},
+}
+
+// resolveActor names who is acting: --as, then ND_ACTOR, then config created_by.
+func resolveActor(cmd *cobra.Command, s *store.Store) string {
+	if as, _ := cmd.Flags().GetString("as"); as != "" {
+		return as
+	}
+	if env := os.Getenv("ND_ACTOR"); env != "" {
+		return env
+	}
+	return s.Config().CreatedBy
+}
+
+func init() {
+	claimCmd.Flags().Bool("from-ready", false, "pick the highest-priority ready issue (the default without an ID)")
+	claimCmd.Flags().String("label", "", "only pick ready issues with this label")
+	claimCmd.Flags().Duration("lease", store.DefaultLease, "lease length before nd reap may release the issue")
+	claimCmd.Flags().String("as", "", "actor claiming the issue (default: $ND_ACTOR, then config created_by)")
+	heartbeatCmd.Flags().Duration("lease", store.DefaultLease, "new lease length, counted from now")
+	heartbeatCmd.Flags().String("as", "", "actor holding the lease (default: $ND_ACTOR, then config created_by)")
+	rootCmd.AddCommand(claimCmd)
+	rootCmd.AddCommand(heartbeatCmd)
+	rootCmd.AddCommand(reapCmd)
+}
@@ -17,6 +17,7 @@ var closeCmd = &cobra.Command{
 	Args:  cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		reason, _ := cmd.Flags().GetString("reason")
+		force, _ := cmd.Flags().GetBool("force")
 
 		s, err := store.Open(resolveVaultDir())
 		if err != nil {
@@ -25,13 +26,21 @@ var closeCmd = &cobra.Command{
 		defer s.Close()
 
 		var errors []string
-		for _, id := range args {
-			if err := s.CloseIssue(id, reason); err != nil {
+		for _, arg := range args {
+			id, err := s.ResolveID(arg)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("%s: %v", arg, err))
+				continue
+			}
+			if err := s.CloseIssue(id, reason, store.TransitionOptions{Force: force}); err != nil {
 				errors = append(errors, fmt.Sprintf("%s: %v", id, err))
 				continue
 			}
 			if !quiet {
 				fmt.Printf("Closed %s\n", id)
+				if next := nextRecurrence(s, id); next != nil {
+					fmt.Printf("  Scheduled %s (recurs %s, deferred until %s)\n", next.ID, next.Recur, next.DeferUntil)
+				}
 			}
 
 			// Cascade: remove this issue from dependents' blocked_by lists.
@@ -80,9 +89,24 @@ var closeCmd = &cobra.Command{
 	},
 }
 
+// nextRecurrence returns the open instance a just-closed recurring issue
+// spawned, or nil when the issue does not recur.
+func nextRecurrence(s *store.Store, id string) *model.Issue {
+	closed, err := s.ReadIssue(id)
+	if err != nil || closed.Recur == "" {
+		return nil
+	}
+	for i := len(closed.LedTo) - 1; i >= 0; i-- {
+		if next, err := s.ReadIssue(closed.LedTo[i]); err == nil && next.Recur != "" && next.Status != model.StatusClosed {
+			return next
+		}
+	}
+	return nil
+}
+
 func init() {
 	closeCmd.Flags().String("reason", "", "close reason")
-	closeCmd.Flags().Bool("force", false, "close even if blocked")
+	closeCmd.Flags().Bool("force", false, "close even with unchecked acceptance criteria (logged in history)")
 	closeCmd.Flags().Bool("suggest-next", false, "show top ready issue after closing")
 	closeCmd.Flags().String("start", "", "start the next issue after closing")
 	rootCmd.AddCommand(closeCmd)
@@ -28,9 +28,13 @@ var commentsAddCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		// Verify issue exists.
 		if _, err := s.ReadIssue(id); err != nil {
-			return fmt.Errorf("issue %s not found: %w", id, err)
+			return err
 		}
 
 		now := time.Now().UTC().Format(time.RFC3339)
@@ -60,6 +64,10 @@ var commentsListCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		content, err := s.Vault().Read(id, "Comments")
 		if err != nil {
 			return err
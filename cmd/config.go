@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -60,16 +65,172 @@ var configListCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
-		for _, entry := range s.ConfigEntries() {
-			fmt.Printf("%-20s %s\n", entry[0], entry[1])
+		entries := s.ConfigEntries()
+		width := 20
+		for _, entry := range entries {
+			if len(entry[0]) >= width {
+				width = len(entry[0]) + 1
+			}
+		}
+		for _, entry := range entries {
+			fmt.Printf("%-*s %s\n", width, entry[0], entry[1])
+		}
+		return nil
+	},
+}
+
+var configWorkflowsCmd = &cobra.Command{
+	Use:   "workflows [name]",
+	Short: "Show and validate per-type workflows",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		names := s.WorkflowNames()
+		if len(args) == 1 {
+			names = []string{strings.ToLower(args[0])}
+		}
+		var workflows []store.Workflow
+		for _, name := range names {
+			wf, err := s.Workflow(name)
+			if err != nil {
+				return err
+			}
+			workflows = append(workflows, wf)
+		}
+		problems := s.ValidateWorkflows()
+
+		if jsonOut {
+			msgs := make([]string, len(problems))
+			for i, p := range problems {
+				msgs[i] = p.Error()
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(map[string]any{"workflows": workflows, "problems": msgs}); err != nil {
+				return err
+			}
+		} else {
+			for i, wf := range workflows {
+				if i > 0 {
+					fmt.Println()
+				}
+				printWorkflow(wf)
+			}
+			if len(problems) > 0 {
+				fmt.Println()
+				for _, p := range problems {
+					fmt.Printf("INVALID %v\n", p)
+				}
+			}
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%d workflow problem(s)", len(problems))
 		}
 		return nil
 	},
 }
 
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check .nd.yaml and report every problem with its line number",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Deliberately not store.Open: Open refuses invalid configs.
+		problems, err := store.ValidateConfig(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			if problems == nil {
+				problems = []store.ConfigProblem{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(map[string]any{"valid": len(problems) == 0, "problems": problems}); err != nil {
+				return err
+			}
+		} else {
+			for _, p := range problems {
+				fmt.Printf(".nd.yaml:%d: %s\n", p.Line, p.Message)
+			}
+			if len(problems) == 0 && !quiet {
+				fmt.Println(".nd.yaml is valid")
+			}
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%d config problem(s)", len(problems))
+		}
+		return nil
+	},
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Convert legacy status_* keys to the structured workflow: schema",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+		data, err := s.MigrateConfig(dryRun)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			fmt.Print(string(data))
+			return nil
+		}
+		if !quiet {
+			fmt.Println("Migrated .nd.yaml to the workflow: schema")
+		}
+		return nil
+	},
+}
+
+func printWorkflow(wf store.Workflow) {
+	fmt.Printf("%s\n", wf.Name)
+	seq := "(none)"
+	if len(wf.Sequence) > 0 {
+		parts := make([]string, len(wf.Sequence))
+		for i, st := range wf.Sequence {
+			parts[i] = string(st)
+		}
+		seq = strings.Join(parts, " -> ")
+	}
+	fmt.Printf("  sequence:   %s\n", seq)
+	if len(wf.ExitRules) == 0 {
+		fmt.Printf("  exit rules: (none)\n")
+		return
+	}
+	fmt.Printf("  exit rules:\n")
+	froms := make([]string, 0, len(wf.ExitRules))
+	for from := range wf.ExitRules {
+		froms = append(froms, string(from))
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		targets := wf.ExitRules[model.Status(from)]
+		parts := make([]string, len(targets))
+		for i, t := range targets {
+			parts[i] = string(t)
+		}
+		fmt.Printf("    %s -> %s\n", from, strings.Join(parts, ", "))
+	}
+}
+
 func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configWorkflowsCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configMigrateCmd.Flags().Bool("dry-run", false, "print the migrated config without writing it")
+	configCmd.AddCommand(configMigrateCmd)
 	rootCmd.AddCommand(configCmd)
 }
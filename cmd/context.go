@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var contextCmd = &cobra.Command{
+	Use:   "context <id>",
+	Short: "Assemble a token-budgeted context pack for handing off an issue",
+	Long: `Gathers what an agent needs to pick up an issue, in priority order:
+the issue itself (without History and Comments), its parent epic, open
+blockers, predecessors on the follows chain with their close reasons,
+recent comments, and related issues.
+
+With --budget, sections that do not fit fall back to a summary, then are
+truncated, then omitted; the issue itself is always kept. Tokens are
+estimated at about four characters each. Output is markdown, or JSON
+with --json.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		budget, _ := cmd.Flags().GetInt("budget")
+		if budget < 0 {
+			return fmt.Errorf("--budget must not be negative")
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		id, err := s.ResolveID(args[0])
+		if err != nil {
+			return err
+		}
+		issue, err := s.ReadIssue(id)
+		if err != nil {
+			return err
+		}
+		all, err := s.ListIssues(store.FilterOptions{})
+		if err != nil {
+			return err
+		}
+
+		pack := format.BuildContext(issue, all, budget)
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(pack)
+		}
+		format.WriteContextMarkdown(os.Stdout, pack)
+		return nil
+	},
+}
+
+func init() {
+	contextCmd.Flags().Int("budget", 0, "approximate token budget (0 = unlimited)")
+	rootCmd.AddCommand(contextCmd)
+}
@@ -5,7 +5,10 @@ import (
 	"io"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -33,6 +36,9 @@ var createCmd = &cobra.Command{
 		description, _ := cmd.Flags().GetString("description")
 		parent, _ := cmd.Flags().GetString("parent")
 		bodyFile, _ := cmd.Flags().GetString("body-file")
+		recur, _ := cmd.Flags().GetString("recur")
+		due, _ := cmd.Flags().GetString("due")
+		estimate, _ := cmd.Flags().GetString("estimate")
 
 		if bodyFile != "" {
 			body, err := readBodyFile(bodyFile)
@@ -62,7 +68,37 @@ var createCmd = &cobra.Command{
 		}
 		defer s.Close()
 
-		issue, err := s.CreateIssue(title, description, issueType, priority, assignee, labels, parent)
+		if parent != "" {
+			if parent, err = s.ResolveID(parent); err != nil {
+				return err
+			}
+		}
+
+		tmpl, err := resolveTemplate(cmd, s, &issueType, &priority, &assignee, &labels)
+		if err != nil {
+			return err
+		}
+		if recur != "" {
+			rec, err := dates.ParseRecurrence(recur)
+			if err != nil {
+				return usageError{err}
+			}
+			recur = rec.String()
+		}
+		if due != "" {
+			if due, err = dates.Resolve(due, time.Now()); err != nil {
+				return usageError{fmt.Errorf("invalid --due: %w", err)}
+			}
+		}
+		if estimate != "" {
+			d, err := model.ParseEffort(estimate)
+			if err != nil {
+				return usageError{err}
+			}
+			estimate = model.FormatEffort(d)
+		}
+
+		issue, err := s.CreateIssue(title, description, issueType, priority, assignee, labels, parent, store.CreateOptions{Template: tmpl, Recur: recur, Due: due, Estimate: estimate})
 		if err != nil {
 			return err
 		}
@@ -88,9 +124,56 @@ func init() {
 	createCmd.Flags().StringP("description", "d", "", "issue description")
 	createCmd.Flags().String("parent", "", "parent issue ID")
 	createCmd.Flags().String("body-file", "", "read description from file (- for stdin)")
+	createCmd.Flags().String("estimate", "", "effort estimate (e.g. 4h, 1.5d; d=8h, w=5d)")
+	createCmd.Flags().String("due", "", "due date (YYYY-MM-DD, tomorrow, +3d, ...); default from due.sla for the priority")
+	createCmd.Flags().String("recur", "", "recurrence rule: closing spawns the next instance (e.g. 2w, monthly, \"0 9 * * 1\")")
+	createCmd.Flags().String("template", "", "body template from <vault>/templates/<name>.md (default: template named after --type, if any)")
 	rootCmd.AddCommand(createCmd)
 }
 
+// resolveTemplate picks the body template for a new issue. An explicit
+// --template wins; otherwise a vault template named after the issue type is
+// used when one exists. Template frontmatter supplies type, priority, assignee,
+// and labels for any of those flags the user did not set.
+func resolveTemplate(cmd *cobra.Command, s *store.Store, issueType *string, priority *int, assignee *string, labels *[]string) (*store.Template, error) {
+	name, _ := cmd.Flags().GetString("template")
+
+	var tmpl *store.Template
+	var err error
+	if name != "" {
+		tmpl, err = s.LoadTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		if tmpl.Type != "" && !cmd.Flags().Changed("type") {
+			*issueType = tmpl.Type
+		}
+	} else {
+		itype, err := model.ParseIssueType(*issueType)
+		if err != nil {
+			return nil, usageError{err}
+		}
+		tmpl, err = s.TemplateForType(itype)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if tmpl == nil {
+		return nil, nil
+	}
+
+	if tmpl.Priority != nil && !cmd.Flags().Changed("priority") {
+		*priority = *tmpl.Priority
+	}
+	if tmpl.Assignee != "" && !cmd.Flags().Changed("assignee") {
+		*assignee = tmpl.Assignee
+	}
+	if len(tmpl.Labels) > 0 && !cmd.Flags().Changed("labels") {
+		*labels = tmpl.Labels
+	}
+	return tmpl, nil
+}
+
 // readBodyFile reads content from a file path or stdin (when path is "-").
 func readBodyFile(path string) (string, error) {
 	var data []byte
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+type criticalItemJSON struct {
+	ID             string  `json:"id"`
+	Title          string  `json:"title"`
+	Status         string  `json:"status"`
+	Assignee       string  `json:"assignee,omitempty"`
+	Weight         string  `json:"weight"`
+	WeightHours    float64 `json:"weight_hours"`
+	Estimated      bool    `json:"estimated"`
+	EarliestStartH float64 `json:"earliest_start_hours"`
+	LatestStartH   float64 `json:"latest_start_hours"`
+	Slack          string  `json:"slack"`
+	SlackHours     float64 `json:"slack_hours"`
+	Critical       bool    `json:"critical"`
+}
+
+type criticalPathJSON struct {
+	Scope          string             `json:"scope,omitempty"`
+	Chain          []criticalItemJSON `json:"chain"`
+	Issues         []criticalItemJSON `json:"issues"`
+	LengthHours    float64            `json:"length_hours"`
+	RemainingHours float64            `json:"remaining_hours"`
+	ThroughputDay  float64            `json:"throughput_hours_per_day"`
+	WindowDays     int                `json:"window_days"`
+	ClosedInWindow int                `json:"closed_in_window"`
+	ProjectedEnd   string             `json:"projected_finish,omitempty"`
+}
+
+var criticalPathCmd = &cobra.Command{
+	Use:   "critical-path [epic]",
+	Short: "Show the longest chain of open work and project a finish date",
+	Long: `Schedules open issues over their blocked_by edges and reports the
+longest chain of remaining work, ranked in dependency order, plus the slack
+of every other open issue. Weights are remaining estimates (estimate minus
+spent); unestimated issues count as one working day (8h).
+
+The finish date is projected from throughput: the effort closed per day over
+the last --window days, applied to all remaining work in scope.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		window, _ := cmd.Flags().GetInt("window")
+		if window <= 0 {
+			return fmt.Errorf("--window must be a positive number of days")
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		all, err := s.ListIssues(store.FilterOptions{})
+		if err != nil {
+			return err
+		}
+		g := graph.Build(all)
+
+		var scope map[string]bool
+		scopeID := ""
+		history := all
+		if len(args) == 1 {
+			if scopeID, err = s.ResolveID(args[0]); err != nil {
+				return err
+			}
+			scope = g.Descendants(scopeID)
+			if scope == nil {
+				return s.NotFound(scopeID)
+			}
+			history = nil
+			for _, issue := range all {
+				if scope[issue.ID] {
+					history = append(history, issue)
+				}
+			}
+		}
+
+		sched, err := g.CriticalPath(scope)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		rate, closed := graph.Throughput(history, now, time.Duration(window)*24*time.Hour)
+		finish, projected := graph.ProjectFinish(now, sched.Remaining, rate)
+
+		if jsonOut {
+			out := criticalPathJSON{
+				Scope:          scopeID,
+				Chain:          []criticalItemJSON{},
+				Issues:         []criticalItemJSON{},
+				LengthHours:    sched.Length.Hours(),
+				RemainingHours: sched.Remaining.Hours(),
+				ThroughputDay:  rate.Hours(),
+				WindowDays:     window,
+				ClosedInWindow: closed,
+			}
+			for _, it := range sched.Chain {
+				out.Chain = append(out.Chain, criticalItemToJSON(it))
+			}
+			for _, it := range sched.Items {
+				out.Issues = append(out.Issues, criticalItemToJSON(it))
+			}
+			if projected {
+				out.ProjectedEnd = finish.Format("2006-01-02")
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+
+		if len(sched.Items) == 0 {
+			fmt.Println("No open work in scope.")
+			return nil
+		}
+
+		fmt.Printf("Critical path: %d issues, %s of work\n", len(sched.Chain), model.FormatEffort(sched.Length))
+		for i, it := range sched.Chain {
+			fmt.Printf("  %d. %s %s %s (%s)\n", i+1, statusIcon(it.Issue.Status), it.Issue.ID, it.Issue.Title, criticalWeight(it))
+		}
+
+		var slack []*graph.ScheduleItem
+		for _, it := range sched.Items {
+			if !onChain(sched.Chain, it) {
+				slack = append(slack, it)
+			}
+		}
+		if len(slack) > 0 {
+			fmt.Println()
+			fmt.Println("Slack:")
+			for _, it := range slack {
+				fmt.Printf("  %-8s %s %s (%s)\n", model.FormatEffort(it.Slack), it.Issue.ID, it.Issue.Title, criticalWeight(it))
+			}
+		}
+
+		fmt.Println()
+		fmt.Printf("Remaining: %s across %d open issues\n", model.FormatEffort(sched.Remaining), len(sched.Items))
+		if projected {
+			fmt.Printf("Projected finish: %s (%s/day over the last %dd, %d closed)\n",
+				finish.Format("2006-01-02"), model.FormatEffort(rate), window, closed)
+		} else {
+			fmt.Printf("Projected finish: unknown (nothing closed in the last %dd)\n", window)
+		}
+		return nil
+	},
+}
+
+func criticalItemToJSON(it *graph.ScheduleItem) criticalItemJSON {
+	return criticalItemJSON{
+		ID:             it.Issue.ID,
+		Title:          it.Issue.Title,
+		Status:         string(it.Issue.Status),
+		Assignee:       it.Issue.Assignee,
+		Weight:         model.FormatEffort(it.Weight),
+		WeightHours:    it.Weight.Hours(),
+		Estimated:      it.Estimated,
+		EarliestStartH: it.EarliestStart.Hours(),
+		LatestStartH:   it.LatestStart.Hours(),
+		Slack:          model.FormatEffort(it.Slack),
+		SlackHours:     it.Slack.Hours(),
+		Critical:       it.Critical(),
+	}
+}
+
+// criticalWeight renders an item's weight, marking unit weights.
+func criticalWeight(it *graph.ScheduleItem) string {
+	if it.Estimated {
+		return model.FormatEffort(it.Weight)
+	}
+	return model.FormatEffort(it.Weight) + " unestimated"
+}
+
+func onChain(chain []*graph.ScheduleItem, it *graph.ScheduleItem) bool {
+	for _, c := range chain {
+		if c == it {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	criticalPathCmd.Flags().Int("window", 28, "days of closed work used to measure throughput")
+	rootCmd.AddCommand(criticalPathCmd)
+}
@@ -21,12 +21,20 @@ var deferCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		if err := s.DeferIssue(id, until); err != nil {
 			return err
 		}
 
 		if !quiet {
 			if until != "" {
+				issue, err := s.ReadIssue(id)
+				if err == nil {
+					until = issue.DeferUntil
+				}
 				fmt.Printf("Deferred %s until %s\n", id, until)
 			} else {
 				fmt.Printf("Deferred %s\n", id)
@@ -37,6 +45,6 @@ var deferCmd = &cobra.Command{
 }
 
 func init() {
-	deferCmd.Flags().String("until", "", "defer until date (YYYY-MM-DD)")
+	deferCmd.Flags().String("until", "", "defer until date (YYYY-MM-DD, tomorrow, or offset like +2w)")
 	rootCmd.AddCommand(deferCmd)
 }
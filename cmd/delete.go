@@ -23,7 +23,12 @@ var deleteCmd = &cobra.Command{
 		defer s.Close()
 
 		var errors []string
-		for _, id := range args {
+		for _, arg := range args {
+			id, err := s.ResolveID(arg)
+			if err != nil {
+				errors = append(errors, fmt.Sprintf("%s: %v", arg, err))
+				continue
+			}
 			if dryRun {
 				issue, err := s.ReadIssue(id)
 				if err != nil {
@@ -27,6 +27,14 @@ var depAddCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if issueID, err = s.ResolveID(issueID); err != nil {
+			return err
+		}
+		if depID, err = s.ResolveID(depID); err != nil {
+			return err
+		}
+
 		if err := s.AddDependency(issueID, depID); err != nil {
 			return err
 		}
@@ -48,6 +56,14 @@ var depRmCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if issueID, err = s.ResolveID(issueID); err != nil {
+			return err
+		}
+		if depID, err = s.ResolveID(depID); err != nil {
+			return err
+		}
+
 		if err := s.RemoveDependency(issueID, depID); err != nil {
 			return err
 		}
@@ -69,6 +85,11 @@ var depListCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		issue, err := s.ReadIssue(id)
 		if err != nil {
 			return err
@@ -125,6 +146,14 @@ var depRelateCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if a, err = s.ResolveID(a); err != nil {
+			return err
+		}
+		if b, err = s.ResolveID(b); err != nil {
+			return err
+		}
+
 		if err := s.AddRelated(a, b); err != nil {
 			return err
 		}
@@ -146,6 +175,14 @@ var depUnrelateCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if a, err = s.ResolveID(a); err != nil {
+			return err
+		}
+		if b, err = s.ResolveID(b); err != nil {
+			return err
+		}
+
 		if err := s.RemoveRelated(a, b); err != nil {
 			return err
 		}
@@ -193,6 +230,11 @@ var depTreeCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		all, err := s.ListIssues(store.FilterOptions{})
 		if err != nil {
 			return err
@@ -200,7 +242,7 @@ var depTreeCmd = &cobra.Command{
 		g := graph.Build(all)
 		tree := g.DepTree(id)
 		if tree == nil {
-			return fmt.Errorf("issue %s not found", id)
+			return s.NotFound(id)
 		}
 		printDepTree(os.Stdout, tree, "", true)
 		return nil
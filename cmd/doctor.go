@@ -142,6 +142,36 @@ var doctorCmd = &cobra.Command{
 			}
 		}
 
+		// Check 6: Required sections from per-type templates.
+		templates := make(map[string]*store.Template)
+		for _, issue := range issues {
+			tmpl, ok := templates[string(issue.Type)]
+			if !ok {
+				tmpl, err = s.TemplateForType(issue.Type)
+				if err != nil {
+					errorf("template %s: %v", issue.Type, err)
+				}
+				templates[string(issue.Type)] = tmpl
+			}
+			if tmpl == nil {
+				continue
+			}
+			missing := store.MissingSections(issue.Body, tmpl.Sections())
+			if len(missing) == 0 {
+				continue
+			}
+			fmt.Printf("[SECTION] %s: missing %s required for type %s\n",
+				issue.ID, strings.Join(missing, ", "), issue.Type)
+			problems++
+			if fix {
+				if err := s.EnsureSections(issue.ID, missing); err != nil {
+					errorf("fix sections %s: %v", issue.ID, err)
+				} else {
+					fmt.Printf("  -> added empty sections\n")
+				}
+			}
+		}
+
 		if problems == 0 {
 			fmt.Printf("All %d issues passed validation.\n", len(issues))
 		} else {
@@ -22,9 +22,13 @@ var editCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		// Verify issue exists.
 		if _, err := s.ReadIssue(id); err != nil {
-			return fmt.Errorf("issue %s not found: %w", id, err)
+			return err
 		}
 
 		editor := os.Getenv("EDITOR")
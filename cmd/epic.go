@@ -1,15 +1,36 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/RamXX/nd/internal/format"
 	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
 
+// epicProgressJSON is the progress block of nd epic status --json.
+type epicProgressJSON struct {
+	Total      int    `json:"total"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	Blocked    int    `json:"blocked"`
+	Closed     int    `json:"closed"`
+	Estimated  string `json:"estimated"`
+	Spent      string `json:"spent"`
+	Remaining  string `json:"remaining"`
+}
+
+type epicStatusJSON struct {
+	SchemaVersion int              `json:"schema_version"`
+	Issue         format.IssueJSON `json:"issue"`
+	Progress      epicProgressJSON `json:"progress"`
+}
+
 var epicCmd = &cobra.Command{
 	Use:   "epic",
 	Short: "Epic management commands",
@@ -27,6 +48,10 @@ var epicStatusCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		all, err := s.ListIssues(store.FilterOptions{})
 		if err != nil {
 			return err
@@ -35,7 +60,26 @@ var epicStatusCmd = &cobra.Command{
 		g := graph.Build(all)
 		summary := g.EpicStatus(id)
 		if summary == nil {
-			return fmt.Errorf("epic %s not found", id)
+			return s.NotFound(id)
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(epicStatusJSON{
+				SchemaVersion: format.JSONSchemaVersion,
+				Issue:         format.ToJSON(summary.Epic),
+				Progress: epicProgressJSON{
+					Total:      summary.Total,
+					Open:       summary.Open,
+					InProgress: summary.InProgress,
+					Blocked:    summary.Blocked,
+					Closed:     summary.Closed,
+					Estimated:  model.FormatEffort(summary.Estimated),
+					Spent:      model.FormatEffort(summary.Spent),
+					Remaining:  model.FormatEffort(summary.Remaining),
+				},
+			})
 		}
 
 		fmt.Printf("Epic: %s - %s\n", summary.Epic.ID, summary.Epic.Title)
@@ -48,6 +92,12 @@ var epicStatusCmd = &cobra.Command{
 			pct := float64(summary.Closed) / float64(summary.Total) * 100
 			fmt.Printf("  Progress:    %.0f%%\n", pct)
 		}
+		if summary.Estimated > 0 || summary.Spent > 0 {
+			fmt.Println("Effort:")
+			fmt.Printf("  Estimated:   %s\n", model.FormatEffort(summary.Estimated))
+			fmt.Printf("  Spent:       %s\n", model.FormatEffort(summary.Spent))
+			fmt.Printf("  Remaining:   %s\n", model.FormatEffort(summary.Remaining))
+		}
 		return nil
 	},
 }
@@ -64,6 +114,10 @@ var epicTreeCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		all, err := s.ListIssues(store.FilterOptions{})
 		if err != nil {
 			return err
@@ -72,9 +126,14 @@ var epicTreeCmd = &cobra.Command{
 		g := graph.Build(all)
 		tree := g.EpicTree(id)
 		if tree == nil {
-			return fmt.Errorf("epic %s not found", id)
+			return s.NotFound(id)
 		}
 
+		if jsonOut {
+			var issues []*model.Issue
+			root := epicNodeJSON(tree, &issues)
+			return format.JSONTree(os.Stdout, issues, []format.NodeJSON{root})
+		}
 		printTree(os.Stdout, tree, 0)
 		return nil
 	},
@@ -99,6 +158,16 @@ func printTree(w *os.File, node *graph.EpicNode, depth int) {
 	}
 }
 
+// epicNodeJSON converts an epic tree for JSON output, collecting its issues.
+func epicNodeJSON(node *graph.EpicNode, issues *[]*model.Issue) format.NodeJSON {
+	*issues = append(*issues, node.Issue)
+	out := format.NodeJSON{ID: node.Issue.ID}
+	for _, child := range node.Children {
+		out.Children = append(out.Children, epicNodeJSON(child, issues))
+	}
+	return out
+}
+
 var epicCloseEligibleCmd = &cobra.Command{
 	Use:   "close-eligible",
 	Short: "List epics where all children are closed",
@@ -117,18 +186,23 @@ var epicCloseEligibleCmd = &cobra.Command{
 		g := graph.Build(all)
 		epics := g.Epics()
 
-		found := false
+		var eligible []*model.Issue
 		for _, epic := range epics {
 			if epic.Status == "closed" {
 				continue
 			}
 			summary := g.EpicStatus(epic.ID)
 			if summary != nil && summary.Total > 0 && summary.Closed == summary.Total {
-				fmt.Printf("%s %s (%d/%d closed)\n", epic.ID, epic.Title, summary.Closed, summary.Total)
-				found = true
+				eligible = append(eligible, epic)
+				if !jsonOut {
+					fmt.Printf("%s %s (%d/%d closed)\n", epic.ID, epic.Title, summary.Closed, summary.Total)
+				}
 			}
 		}
-		if !found {
+		if jsonOut {
+			return format.JSON(os.Stdout, eligible)
+		}
+		if len(eligible) == 0 {
 			fmt.Println("No close-eligible epics found.")
 		}
 		return nil
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes. They are part of the CLI contract; see "Exit Codes" in the
+// README.
+const (
+	exitError      = 1 // any other failure
+	exitBlocked    = 2 // nd guard refused a tool call
+	exitNotFound   = 3
+	exitTransition = 4 // FSM, guard, WIP limit, or acceptance gate refused a status change
+	exitLocked     = 5 // vault lock busy, or issue claimed by another actor
+	exitValidation = 6 // invalid flags, arguments, or values
+)
+
+// usageError marks a bad flag, argument count, or flag value; it exits like
+// a validation error.
+type usageError struct{ error }
+
+func (e usageError) Unwrap() error { return e.error }
+
+// reportedError is returned by a command that already printed its failures
+// as JSON; it sets the exit code without printing a second error object.
+type reportedError struct{ error }
+
+func (e reportedError) Unwrap() error { return e.error }
+
+type errorJSON struct {
+	SchemaVersion int             `json:"schema_version"`
+	Error         errorDetailJSON `json:"error"`
+}
+
+type errorDetailJSON struct {
+	Code        string   `json:"code"` // not_found, transition, locked, validation, error
+	ExitCode    int      `json:"exit_code"`
+	Message     string   `json:"message"`
+	ID          string   `json:"id,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"` // closest existing IDs
+	Candidates  []string `json:"candidates,omitempty"`  // IDs an ambiguous short ID matches
+	From        string   `json:"from,omitempty"`
+	To          string   `json:"to,omitempty"`
+	Allowed     []string `json:"allowed,omitempty"` // statuses the issue may move to
+}
+
+// errorCode classifies err by the store's error kinds.
+func errorCode(err error) (string, int) {
+	var usage usageError
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return "not_found", exitNotFound
+	case errors.Is(err, store.ErrFSMTransition):
+		return "transition", exitTransition
+	case errors.Is(err, store.ErrLocked):
+		return "locked", exitLocked
+	case errors.Is(err, store.ErrValidation), errors.As(err, &usage):
+		return "validation", exitValidation
+	default:
+		return "error", exitError
+	}
+}
+
+// reportError prints err, as a JSON error object on stdout with --json or
+// as "Error: ..." on stderr otherwise, and returns the exit code.
+func reportError(err error) int {
+	code, exit := errorCode(err)
+	var reported reportedError
+	if errors.As(err, &reported) {
+		return exit
+	}
+	if !jsonOut {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		return exit
+	}
+
+	detail := errorDetailJSON{Code: code, ExitCode: exit, Message: err.Error()}
+	var notFound *store.NotFoundError
+	var ambiguous *store.AmbiguousIDError
+	var transition *store.TransitionError
+	switch {
+	case errors.As(err, &notFound):
+		detail.ID, detail.Suggestions = notFound.ID, notFound.Suggestions
+	case errors.As(err, &ambiguous):
+		detail.ID, detail.Candidates = ambiguous.Input, ambiguous.Candidates
+	case errors.As(err, &transition):
+		detail.ID, detail.From, detail.To = transition.ID, string(transition.From), string(transition.To)
+		for _, st := range transition.Allowed {
+			detail.Allowed = append(detail.Allowed, string(st))
+		}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if encErr := enc.Encode(errorJSON{SchemaVersion: format.JSONSchemaVersion, Error: detail}); encErr != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	return exit
+}
+
+// markUsageErrors wraps the Args validator of c and its subcommands so
+// argument-count errors are reported as validation errors.
+func markUsageErrors(c *cobra.Command) {
+	if validate := c.Args; validate != nil {
+		c.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validate(cmd, args); err != nil {
+				return usageError{err}
+			}
+			return nil
+		}
+	}
+	for _, child := range c.Commands() {
+		markUsageErrors(child)
+	}
+}
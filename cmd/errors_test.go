@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/RamXX/nd/internal/store"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+		exit int
+	}{
+		{&store.NotFoundError{ID: "X-1"}, "not_found", exitNotFound},
+		{fmt.Errorf("dependency X-1: %w", &store.NotFoundError{ID: "X-1"}), "not_found", exitNotFound},
+		{&store.TransitionError{Err: errors.New("no")}, "transition", exitTransition},
+		{fmt.Errorf("lock vault: %w", store.ErrLocked), "locked", exitLocked},
+		{usageError{errors.New("unknown flag: --x")}, "validation", exitValidation},
+		{errors.New("disk full"), "error", exitError},
+	}
+	for _, tt := range tests {
+		code, exit := errorCode(tt.err)
+		if code != tt.code || exit != tt.exit {
+			t.Errorf("errorCode(%v) = %s, %d; want %s, %d", tt.err, code, exit, tt.code, tt.exit)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/RamXX/nd/internal/dates"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -18,11 +19,13 @@ func addFilterFlags(cmd *cobra.Command) {
 	cmd.Flags().StringP("priority", "p", "", "filter by priority (0-4 or P0-P4)")
 	cmd.Flags().String("parent", "", "filter by parent issue ID")
 	cmd.Flags().Bool("no-parent", false, "show only issues with no parent")
-	cmd.Flags().String("created-after", "", "filter by created date (YYYY-MM-DD)")
-	cmd.Flags().String("created-before", "", "filter by created date (YYYY-MM-DD)")
-	cmd.Flags().String("updated-after", "", "filter by updated date (YYYY-MM-DD)")
-	cmd.Flags().String("updated-before", "", "filter by updated date (YYYY-MM-DD)")
-	cmd.Flags().String("sort", "priority", "sort by: priority, created, updated, id")
+	cmd.Flags().String("created-after", "", "filter by created date (YYYY-MM-DD or offset like -1w)")
+	cmd.Flags().String("created-before", "", "filter by created date (YYYY-MM-DD or offset like -1w)")
+	cmd.Flags().String("updated-after", "", "filter by updated date (YYYY-MM-DD or offset like -1w)")
+	cmd.Flags().String("updated-before", "", "filter by updated date (YYYY-MM-DD or offset like -1w)")
+	cmd.Flags().String("defer-after", "", "deferred issues waking after date (YYYY-MM-DD or offset like +1w)")
+	cmd.Flags().String("defer-before", "", "deferred issues waking by date (YYYY-MM-DD or offset like +1w)")
+	cmd.Flags().String("sort", "priority", "sort by: priority, created, updated, due, id")
 	cmd.Flags().BoolP("reverse", "r", false, "reverse sort order")
 	cmd.Flags().IntP("limit", "n", 0, "max results (0 for unlimited)")
 }
@@ -42,6 +45,8 @@ func buildFilterOptions(cmd *cobra.Command, defaultStatus string) (store.FilterO
 	createdBeforeStr, _ := cmd.Flags().GetString("created-before")
 	updatedAfterStr, _ := cmd.Flags().GetString("updated-after")
 	updatedBeforeStr, _ := cmd.Flags().GetString("updated-before")
+	deferAfterStr, _ := cmd.Flags().GetString("defer-after")
+	deferBeforeStr, _ := cmd.Flags().GetString("defer-before")
 	sortBy, _ := cmd.Flags().GetString("sort")
 	reverse, _ := cmd.Flags().GetBool("reverse")
 	limit, _ := cmd.Flags().GetInt("limit")
@@ -50,7 +55,7 @@ func buildFilterOptions(cmd *cobra.Command, defaultStatus string) (store.FilterO
 		status = defaultStatus
 	}
 
-	var createdAfter, createdBefore, updatedAfter, updatedBefore time.Time
+	var createdAfter, createdBefore, updatedAfter, updatedBefore, deferAfter, deferBefore time.Time
 	var err error
 	if createdAfter, err = parseDate(createdAfterStr, false); err != nil {
 		return store.FilterOptions{}, fmt.Errorf("invalid --created-after date: %w", err)
@@ -64,6 +69,12 @@ func buildFilterOptions(cmd *cobra.Command, defaultStatus string) (store.FilterO
 	if updatedBefore, err = parseDate(updatedBeforeStr, true); err != nil {
 		return store.FilterOptions{}, fmt.Errorf("invalid --updated-before date: %w", err)
 	}
+	if deferAfter, err = parseDate(deferAfterStr, false); err != nil {
+		return store.FilterOptions{}, fmt.Errorf("invalid --defer-after date: %w", err)
+	}
+	if deferBefore, err = parseDate(deferBeforeStr, true); err != nil {
+		return store.FilterOptions{}, fmt.Errorf("invalid --defer-before date: %w", err)
+	}
 
 	return store.FilterOptions{
 		Status:        status,
@@ -77,24 +88,26 @@ func buildFilterOptions(cmd *cobra.Command, defaultStatus string) (store.FilterO
 		CreatedBefore: createdBefore,
 		UpdatedAfter:  updatedAfter,
 		UpdatedBefore: updatedBefore,
+		DeferAfter:    deferAfter,
+		DeferBefore:   deferBefore,
 		Sort:          sortBy,
 		Reverse:       reverse,
 		Limit:         limit,
 	}, nil
 }
 
-// parseDate parses a YYYY-MM-DD string into a time.Time.
-// If endOfDay is true, adds 24h-1ns to include the entire day.
-// Returns zero time for empty strings.
+// parseDate parses a date flag (see dates.Parse) into a time.Time.
+// If endOfDay is true and the value is day-granular, adds 24h-1ns to
+// include the entire day. Returns zero time for empty strings.
 func parseDate(s string, endOfDay bool) (time.Time, error) {
 	if s == "" {
 		return time.Time{}, nil
 	}
-	t, err := time.Parse("2006-01-02", s)
+	t, err := dates.Parse(s, time.Now())
 	if err != nil {
 		return time.Time{}, err
 	}
-	if endOfDay {
+	if endOfDay && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
 		t = t.Add(24*time.Hour - time.Nanosecond)
 	}
 	return t, nil
@@ -5,6 +5,7 @@ import (
 	"os"
 	"sort"
 
+	"github.com/RamXX/nd/internal/format"
 	"github.com/RamXX/nd/internal/graph"
 	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
@@ -32,10 +33,18 @@ var graphCmd = &cobra.Command{
 
 		if len(args) == 1 {
 			// Show subgraph from a specific issue.
-			id := args[0]
+			id, err := s.ResolveID(args[0])
+			if err != nil {
+				return err
+			}
 			tree := g.DepTree(id)
 			if tree == nil {
-				return fmt.Errorf("issue %s not found", id)
+				return s.NotFound(id)
+			}
+			if jsonOut {
+				var issues []*model.Issue
+				root := depNodeJSON(tree, &issues)
+				return format.JSONTree(os.Stdout, issues, []format.NodeJSON{root})
 			}
 			printGraphNode(tree, "", true)
 			return nil
@@ -44,6 +53,16 @@ var graphCmd = &cobra.Command{
 		// Show all roots (issues that don't block anything and aren't blocked).
 		// Actually: show all "root" nodes in the dep graph (no blockers).
 		roots := findRoots(all)
+		if jsonOut {
+			var issues []*model.Issue
+			var nodes []format.NodeJSON
+			for _, root := range roots {
+				if tree := g.DepTree(root.ID); tree != nil {
+					nodes = append(nodes, depNodeJSON(tree, &issues))
+				}
+			}
+			return format.JSONTree(os.Stdout, issues, nodes)
+		}
 		if len(roots) == 0 {
 			fmt.Println("No dependency graph to display.")
 			return nil
@@ -105,6 +124,17 @@ func printGraphNode(node *graph.DepNode, prefix string, isLast bool) {
 	}
 }
 
+// depNodeJSON converts a dependency tree for JSON output, collecting its
+// issues.
+func depNodeJSON(node *graph.DepNode, issues *[]*model.Issue) format.NodeJSON {
+	*issues = append(*issues, node.Issue)
+	out := format.NodeJSON{ID: node.Issue.ID}
+	for _, child := range node.Children {
+		out.Children = append(out.Children, depNodeJSON(child, issues))
+	}
+	return out
+}
+
 func statusIcon(s model.Status) string {
 	switch s {
 	case model.StatusClosed:
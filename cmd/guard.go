@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // hookInput is the JSON structure Claude Code sends to PreToolUse hooks via stdin.
@@ -40,34 +43,26 @@ var knownCorrections = map[string]string{
 	"view":     "show",
 }
 
-// invalidFlags are flags that do not exist on most nd commands.
-// --format and --output are intentionally excluded because nd archive uses them.
-var invalidFlags = map[string]string{
-	"--compact":   "nd does not have a --compact flag. nd list already shows compact output.",
-	"--wide":      "nd does not have a --wide flag. Use nd show <id> for full detail.",
-	"--color":     "nd does not have a --color flag.",
-	"--no-header": "nd does not have a --no-header flag.",
+// flagHints explains flags agents commonly invent, where the closest real
+// flag by spelling would mislead.
+var flagHints = map[string]string{
+	"--compact":   "nd list already shows compact output.",
+	"--wide":      "Use nd show <id> for full detail.",
+	"--format":    "Use --json for JSON output (only nd archive has --format).",
+	"--output":    "Use --json for JSON output (only nd archive has --output).",
+	"--color":     "nd does not color its output.",
+	"--no-header": "nd list output has no header to drop.",
 }
 
-// invalidFlagsForNonArchive are flags only valid on nd archive.
-// If the nd command segment does NOT contain "archive", these are invalid.
-var invalidFlagsForNonArchive = map[string]string{
-	"--format": "Only nd archive supports --format. For other commands, use --json for JSON output.",
-	"--output": "Only nd archive supports --output. For other commands, use --json for JSON output.",
+// commandWrappers may precede nd in command position (sudo nd, xargs nd).
+var commandWrappers = map[string]bool{
+	"command": true, "env": true, "exec": true, "nohup": true,
+	"pvg": true, "sudo": true, "time": true, "xargs": true,
 }
 
-// ndCmdPattern matches `nd <subcommand>` in command position:
-// bare command, after pipes/&&/;/$(), or after another command like `pvg nd`.
-// Requires whitespace or start-of-string before "nd" to avoid matching
-// "nd" inside paths (e.g., /workspace/nd) or words (find, and, send).
-var ndCmdPattern = regexp.MustCompile(`(?:^|[\s;|&(])nd\s+(\S+)`)
-
-// ndFlagPattern matches `nd ... --flag` patterns
-var ndFlagPattern = regexp.MustCompile(`nd\s+[^|;&]*?(--\S+)`)
-
 var guardCmd = &cobra.Command{
 	Use:    "guard",
-	Short:  "Validate nd commands in Claude Code PreToolUse hooks",
+	Short:  "Validate nd commands and block direct vault writes in Claude Code PreToolUse hooks",
 	Hidden: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var input hookInput
@@ -76,7 +71,16 @@ var guardCmd = &cobra.Command{
 			return nil
 		}
 
-		if input.ToolName != "Bash" {
+		switch input.ToolName {
+		case "Write", "Edit", "MultiEdit":
+			var file fileInput
+			if err := json.Unmarshal(input.ToolInput, &file); err != nil || file.FilePath == "" {
+				return nil
+			}
+			blockVaultWrites([]fileWrite{{Path: file.FilePath, Via: input.ToolName}})
+			return nil
+		case "Bash":
+		default:
 			return nil
 		}
 
@@ -89,76 +93,232 @@ var guardCmd = &cobra.Command{
 			return nil
 		}
 
+		cmds, redirects := shellCommands(bash.Command)
+
+		// Writes into the vault's issues/ directory bypass nd.
+		if strings.Contains(bash.Command, "issues") {
+			var writes []fileWrite
+			for _, r := range redirects {
+				writes = append(writes, fileWrite{Path: r, Via: ">"})
+			}
+			for _, words := range cmds {
+				writes = append(writes, fileWriteTargets(words)...)
+			}
+			blockVaultWrites(writes)
+		}
+
 		// Only validate commands that contain "nd " to avoid false positives.
 		if !strings.Contains(bash.Command, "nd ") {
 			return nil
 		}
 
-		// Build the set of valid subcommands from the cobra command tree.
-		validCmds := buildValidCommandSet(rootCmd)
+		for _, words := range cmds {
+			args, appended := ndInvocation(words)
+			if args == nil {
+				continue
+			}
+			if problem, help := checkInvocation(rootCmd, args, appended); problem != "" {
+				fmt.Fprintf(os.Stderr, "nd guard: %s\nRun \"%s --help\" for valid usage. Consult the nd skill's CLI_REFERENCE.md for the full command reference.\n", problem, help)
+				os.Exit(exitBlocked)
+			}
+		}
+
+		return nil
+	},
+}
+
+// blockVaultWrites exits with status 2, which blocks the tool call, when a
+// write lands in the vault's issues/ directory.
+func blockVaultWrites(writes []fileWrite) {
+	if problem := vaultWriteProblem(writes); problem != "" {
+		fmt.Fprintf(os.Stderr, "nd guard: %s\n", problem)
+		os.Exit(exitBlocked)
+	}
+}
+
+// ndInvocation returns the arguments of an nd call in a simple command, or
+// nil when the command does not run nd. nd must be in command position:
+// first, or after environment assignments and wrappers such as sudo or
+// xargs. appended reports that the wrapper adds arguments of its own.
+func ndInvocation(words []string) (args []string, appended bool) {
+	for i, w := range words {
+		switch {
+		case w == "nd":
+			return append([]string{}, words[i+1:]...), appended
+		case commandWrappers[w]:
+			appended = appended || w == "xargs"
+		case strings.HasPrefix(w, "-"), envAssignmentRe.MatchString(w):
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+var envAssignmentRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
 
-		// Find all nd subcommands in the bash command.
-		matches := ndCmdPattern.FindAllStringSubmatch(bash.Command, -1)
+// checkInvocation walks args down the cobra tree the way cobra would and
+// validates subcommands, flags, and the positional argument count against
+// the real command definitions. It returns a description of the first
+// problem, with a suggestion where one is close, and the command path to
+// point --help at; or "" when the invocation looks valid. Argument counts
+// are not checked when a word may expand to several (variables, globs,
+// substitutions) or when a wrapper appends arguments.
+func checkInvocation(root *cobra.Command, args []string, appended bool) (problem, help string) {
+	cur := root
+	var positional []string
+	countable := !appended
+	for len(args) > 0 {
+		arg := args[0]
+		args = args[1:]
+		switch {
+		case arg == "--":
+			positional = append(positional, args...)
+			args = nil
 
-		seen := make(map[string]bool)
-		for _, match := range matches {
-			subcmd := strings.TrimLeft(match[1], "-")
-			if subcmd == "" || strings.HasPrefix(match[1], "-") {
-				// It's a flag (like nd --version), not a subcommand. Skip.
-				continue
+		case strings.HasPrefix(arg, "--"):
+			name, _, hasValue := strings.Cut(arg[2:], "=")
+			f := lookupFlag(cur, name)
+			if f == nil {
+				msg := fmt.Sprintf("unknown flag \"--%s\" for \"%s\".", name, cur.CommandPath())
+				if hint, ok := flagHints["--"+name]; ok {
+					msg += " " + hint
+				} else if s := closest(name, flagNames(cur)); s != "" {
+					msg += " Did you mean: --" + s + "?"
+				}
+				return msg, cur.CommandPath()
 			}
-			if seen[subcmd] {
-				continue
+			if !hasValue && f.NoOptDefVal == "" {
+				if len(args) == 0 {
+					return fmt.Sprintf("flag --%s of \"%s\" needs a value.", name, cur.CommandPath()), cur.CommandPath()
+				}
+				args = args[1:]
 			}
-			seen[subcmd] = true
 
-			if !validCmds[subcmd] {
-				suggestion := ""
-				if correct, ok := knownCorrections[subcmd]; ok {
-					suggestion = fmt.Sprintf(" Did you mean: nd %s", correct)
+		case strings.HasPrefix(arg, "-") && arg != "-":
+			shorts := arg[1:]
+			for j := 0; j < len(shorts); j++ {
+				f := lookupShorthand(cur, shorts[j:j+1])
+				if f == nil {
+					return fmt.Sprintf("unknown shorthand flag -%c in \"%s\" for \"%s\".", shorts[j], arg, cur.CommandPath()), cur.CommandPath()
+				}
+				if f.NoOptDefVal != "" {
+					continue
 				}
-				fmt.Fprintf(os.Stderr, "nd guard: unknown command \"nd %s\".%s\nRun \"nd <command> --help\" for valid usage. Consult the nd skill's CLI_REFERENCE.md for the full command reference.\n", subcmd, suggestion)
-				os.Exit(2)
+				if j+1 == len(shorts) {
+					if len(args) == 0 {
+						return fmt.Sprintf("flag -%c of \"%s\" needs a value.", shorts[j], cur.CommandPath()), cur.CommandPath()
+					}
+					args = args[1:]
+				}
+				break
 			}
-		}
 
-		// Check for hallucinated flags in nd command segments.
-		flagMatches := ndFlagPattern.FindAllStringSubmatch(bash.Command, -1)
-		isArchive := strings.Contains(bash.Command, "nd archive") || strings.Contains(bash.Command, "nd  archive")
-		for _, match := range flagMatches {
-			flag := match[1]
-			// Strip =value if present (e.g., --format=json -> --format)
-			if idx := strings.Index(flag, "="); idx != -1 {
-				flag = flag[:idx]
+		case len(positional) == 0 && cur.HasSubCommands():
+			if arg == "help" || (arg == "completion" && cur == root) {
+				return "", ""
 			}
-			if msg, ok := invalidFlags[flag]; ok {
-				fmt.Fprintf(os.Stderr, "nd guard: invalid flag %q. %s\nConsult the nd skill's CLI_REFERENCE.md for valid flags.\n", flag, msg)
-				os.Exit(2)
+			if child := findChild(cur, arg); child != nil {
+				cur = child
+				continue
 			}
-			if !isArchive {
-				if msg, ok := invalidFlagsForNonArchive[flag]; ok {
-					fmt.Fprintf(os.Stderr, "nd guard: invalid flag %q. %s\nConsult the nd skill's CLI_REFERENCE.md for valid flags.\n", flag, msg)
-					os.Exit(2)
-				}
+			if cur.Runnable() {
+				positional = append(positional, arg)
+				continue
+			}
+			msg := fmt.Sprintf("unknown command \"%s %s\".", cur.CommandPath(), arg)
+			if correct, ok := knownCorrections[arg]; ok && cur == root {
+				msg += " Did you mean: nd " + correct
+			} else if s := closest(arg, childNames(cur)); s != "" {
+				msg += fmt.Sprintf(" Did you mean: %s %s?", cur.CommandPath(), s)
 			}
+			if cur == root {
+				return msg, "nd <command>"
+			}
+			return msg, cur.CommandPath()
+
+		default:
+			positional = append(positional, arg)
 		}
+	}
 
-		return nil
-	},
+	for _, p := range positional {
+		if strings.ContainsAny(p, "$*?[`") || strings.Contains(p, substitutionWord) {
+			countable = false
+		}
+	}
+	if countable && cur.Args != nil {
+		if err := cur.Args(cur, positional); err != nil {
+			return fmt.Sprintf("invalid arguments for \"%s\": %v.", cur.CommandPath(), err), cur.CommandPath()
+		}
+	}
+	return "", ""
+}
+
+func lookupFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	cmd.InitDefaultHelpFlag()
+	cmd.InitDefaultVersionFlag()
+	if f := cmd.Flags().Lookup(name); f != nil {
+		return f
+	}
+	return cmd.InheritedFlags().Lookup(name)
 }
 
-// buildValidCommandSet returns the set of valid top-level nd subcommand names
-// (including aliases). Only direct children of root are included -- nested
-// subcommands (e.g., "status" under "epic") are NOT valid as top-level commands.
-func buildValidCommandSet(root *cobra.Command) map[string]bool {
-	cmds := make(map[string]bool)
-	for _, child := range root.Commands() {
-		cmds[child.Name()] = true
-		for _, alias := range child.Aliases {
-			cmds[alias] = true
+func lookupShorthand(cmd *cobra.Command, short string) *pflag.Flag {
+	cmd.InitDefaultHelpFlag()
+	if f := cmd.Flags().ShorthandLookup(short); f != nil {
+		return f
+	}
+	return cmd.InheritedFlags().ShorthandLookup(short)
+}
+
+func flagNames(cmd *cobra.Command) []string {
+	var names []string
+	add := func(f *pflag.Flag) {
+		if !f.Hidden {
+			names = append(names, f.Name)
+		}
+	}
+	cmd.Flags().VisitAll(add)
+	cmd.InheritedFlags().VisitAll(add)
+	return names
+}
+
+func findChild(cmd *cobra.Command, name string) *cobra.Command {
+	for _, child := range cmd.Commands() {
+		if child.Name() == name || child.HasAlias(name) {
+			return child
+		}
+	}
+	return nil
+}
+
+func childNames(cmd *cobra.Command) []string {
+	var names []string
+	for _, child := range cmd.Commands() {
+		if !child.Hidden {
+			names = append(names, child.Name())
+		}
+	}
+	return names
+}
+
+// closest returns the candidate nearest to name by edit distance, or ""
+// when none is close. A candidate containing name (--labels in
+// --set-labels) counts as close.
+func closest(name string, candidates []string) string {
+	sort.Strings(candidates)
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := store.EditDistance(name, c)
+		if d > max(2, len(name)/3) && !(len(name) >= 3 && strings.Contains(c, name)) {
+			continue
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
 		}
 	}
-	return cmds
+	return best
 }
 
 func init() {
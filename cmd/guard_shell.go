@@ -0,0 +1,237 @@
+package cmd
+
+import "strings"
+
+// substitutionWord stands in for a $(...) or `...` word. Its contents are
+// lexed as commands of their own.
+const substitutionWord = "$(...)"
+
+// shellCommands splits a bash command string into simple commands, each a
+// list of words with quoting removed, and also returns the files written by
+// output redirections. Commands are separated by ; & | ( ) and newlines.
+// Command substitutions are lexed as separate commands and leave a
+// placeholder word behind. Comments and heredoc bodies are dropped.
+//
+// It is not a full shell parser: it exists to find nd invocations and
+// writes, and it never fails.
+func shellCommands(src string) (cmds [][]string, redirects []string) {
+	l := &shellLexer{src: src}
+	l.run(0)
+	return l.cmds, l.redirects
+}
+
+type heredoc struct {
+	delim     string
+	stripTabs bool
+}
+
+type shellLexer struct {
+	src       string
+	i         int
+	cmds      [][]string
+	redirects []string // output redirection targets
+	words     []string
+	word      strings.Builder
+	inWord    bool // a word is open, possibly empty ("")
+
+	discardNext bool // the next word is an input redirection target
+	outputNext  bool // the next word is an output redirection target
+	heredocNext bool // the next word is a heredoc delimiter
+	stripTabs   bool
+	heredocs    []heredoc // bodies start at the next newline
+}
+
+// run lexes until stop (the closing byte of a substitution) or the end of
+// the input.
+func (l *shellLexer) run(stop byte) {
+	for l.i < len(l.src) {
+		c := l.src[l.i]
+		switch {
+		case stop != 0 && c == stop:
+			l.i++
+			l.endCommand()
+			return
+		case c == '\\':
+			if l.i+1 < len(l.src) && l.src[l.i+1] != '\n' {
+				l.add(l.src[l.i+1])
+			}
+			l.i += 2
+		case c == '\'':
+			end := strings.IndexByte(l.src[l.i+1:], '\'')
+			if end < 0 {
+				end = len(l.src) - l.i - 1
+			}
+			l.word.WriteString(l.src[l.i+1 : l.i+1+end])
+			l.inWord = true
+			l.i += end + 2
+		case c == '"':
+			l.i++
+			l.doubleQuoted()
+		case c == '$' && l.i+1 < len(l.src) && l.src[l.i+1] == '(':
+			l.i += 2
+			l.substitution(')')
+		case c == '`':
+			l.i++
+			l.substitution('`')
+		case c == ' ' || c == '\t':
+			l.endWord()
+			l.i++
+		case c == '\n':
+			l.endCommand()
+			l.i++
+			l.skipHeredocs()
+		case c == ';' || c == '|' || c == '&' || c == '(' || c == ')':
+			l.endCommand()
+			l.i++
+		case c == '#' && !l.inWord:
+			if end := strings.IndexByte(l.src[l.i:], '\n'); end >= 0 {
+				l.i += end
+			} else {
+				l.i = len(l.src)
+			}
+		case c == '>' || c == '<':
+			l.redirect()
+		default:
+			l.add(c)
+			l.i++
+		}
+	}
+	l.endCommand()
+}
+
+func (l *shellLexer) add(c byte) {
+	l.word.WriteByte(c)
+	l.inWord = true
+}
+
+func (l *shellLexer) doubleQuoted() {
+	l.inWord = true
+	for l.i < len(l.src) {
+		c := l.src[l.i]
+		switch {
+		case c == '"':
+			l.i++
+			return
+		case c == '\\' && l.i+1 < len(l.src) && strings.IndexByte("$`\"\\\n", l.src[l.i+1]) >= 0:
+			if l.src[l.i+1] != '\n' {
+				l.word.WriteByte(l.src[l.i+1])
+			}
+			l.i += 2
+		case c == '$' && l.i+1 < len(l.src) && l.src[l.i+1] == '(':
+			l.i += 2
+			l.substitution(')')
+		case c == '`':
+			l.i++
+			l.substitution('`')
+		default:
+			l.word.WriteByte(c)
+			l.i++
+		}
+	}
+}
+
+// substitution lexes the body of $(...) or `...` as commands of its own.
+func (l *shellLexer) substitution(stop byte) {
+	inner := &shellLexer{src: l.src, i: l.i}
+	inner.run(stop)
+	l.cmds = append(l.cmds, inner.cmds...)
+	l.redirects = append(l.redirects, inner.redirects...)
+	l.i = inner.i
+	l.word.WriteString(substitutionWord)
+	l.inWord = true
+}
+
+// redirect takes a redirection and its target out of the command words:
+// 2>&1, >file, <<EOF, <<<str. Output targets are kept in redirects.
+func (l *shellLexer) redirect() {
+	if l.inWord && isDigits(l.word.String()) {
+		l.word.Reset()
+		l.inWord = false
+	} else {
+		l.endWord()
+	}
+	if strings.HasPrefix(l.src[l.i:], "<<") && !strings.HasPrefix(l.src[l.i:], "<<<") {
+		l.i += 2
+		l.stripTabs = l.i < len(l.src) && l.src[l.i] == '-'
+		if l.stripTabs {
+			l.i++
+		}
+		l.heredocNext = true
+		return
+	}
+	output := false
+	for l.i < len(l.src) && strings.IndexByte("<>&|", l.src[l.i]) >= 0 {
+		output = output || l.src[l.i] == '>'
+		l.i++
+	}
+	l.outputNext = output
+	l.discardNext = !output
+}
+
+func (l *shellLexer) endWord() {
+	if !l.inWord {
+		return
+	}
+	w := l.word.String()
+	l.word.Reset()
+	l.inWord = false
+	switch {
+	case l.heredocNext:
+		l.heredocs = append(l.heredocs, heredoc{delim: w, stripTabs: l.stripTabs})
+		l.heredocNext = false
+	case l.outputNext:
+		if !isDigits(w) && w != "-" { // 2>&1, >&-
+			l.redirects = append(l.redirects, w)
+		}
+		l.outputNext = false
+	case l.discardNext:
+		l.discardNext = false
+	default:
+		l.words = append(l.words, w)
+	}
+}
+
+func (l *shellLexer) endCommand() {
+	l.endWord()
+	l.discardNext, l.outputNext = false, false
+	if len(l.words) > 0 {
+		l.cmds = append(l.cmds, l.words)
+	}
+	l.words = nil
+}
+
+// skipHeredocs moves past the bodies of heredocs opened on the line just
+// ended.
+func (l *shellLexer) skipHeredocs() {
+	for _, h := range l.heredocs {
+		for l.i < len(l.src) {
+			line := l.src[l.i:]
+			end := strings.IndexByte(line, '\n')
+			if end >= 0 {
+				line = line[:end]
+				l.i += end + 1
+			} else {
+				l.i = len(l.src)
+			}
+			if h.stripTabs {
+				line = strings.TrimLeft(line, "\t")
+			}
+			if line == h.delim {
+				break
+			}
+		}
+	}
+	l.heredocs = nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
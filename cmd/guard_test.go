@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestShellCommands(t *testing.T) {
+	tests := []struct {
+		in   string
+		want [][]string
+	}{
+		{`nd list --status open`, [][]string{{"nd", "list", "--status", "open"}}},
+		{`cd x && nd show 'A B' | head; echo "nd x"`, [][]string{{"cd", "x"}, {"nd", "show", "A B"}, {"head"}, {"echo", "nd x"}}},
+		{`nd show $(nd list --status=open)`, [][]string{{"nd", "list", "--status=open"}, {"nd", "show", "$(...)"}}},
+		{`nd ready 2>&1 >/dev/null # nd bogus`, [][]string{{"nd", "ready"}}},
+		{"nd comments add X --body-file - <<'EOF'\nnd bogus\nEOF\nnd ready", [][]string{{"nd", "comments", "add", "X", "--body-file", "-"}, {"nd", "ready"}}},
+		{`nd update X -d "say \"hi\""`, [][]string{{"nd", "update", "X", "-d", `say "hi"`}}},
+	}
+	for _, tt := range tests {
+		if got, _ := shellCommands(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("shellCommands(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckInvocation(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // substring of the problem; "" means valid
+	}{
+		{`nd list --status open --json`, ""},
+		{`nd --json epic status E`, ""},
+		{`nd list -s open`, ""},
+		{`nd close A B --reason done`, ""},
+		{`nd close $IDS`, ""},
+		{`nd help update`, ""},
+		{`sudo ND_VAULT_DIR=/v nd ready`, ""},
+		{`nd update X --labels a`, "Did you mean: --set-labels?"},
+		{`nd epic staus E`, "Did you mean: nd epic status?"},
+		{`nd shwo X`, "Did you mean: nd show?"},
+		{`nd find foo`, "Did you mean: nd search"},
+		{`nd list --compact`, "compact output"},
+		{`nd list --format json`, "--json"},
+		{`nd show`, `invalid arguments for "nd show"`},
+		{`nd update X --title`, "needs a value"},
+		{`nd list -z`, "unknown shorthand flag -z"},
+	}
+	for _, tt := range tests {
+		var got string
+		cmds, _ := shellCommands(tt.line)
+		for _, words := range cmds {
+			if args, appended := ndInvocation(words); args != nil {
+				got, _ = checkInvocation(rootCmd, args, appended)
+			}
+		}
+		if tt.want == "" && got != "" {
+			t.Errorf("%s: unexpected problem %q", tt.line, got)
+		}
+		if tt.want != "" && !strings.Contains(got, tt.want) {
+			t.Errorf("%s: problem = %q, want it to contain %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestVaultWriteProblem(t *testing.T) {
+	vault := filepath.Join(t.TempDir(), ".vault")
+	if err := os.MkdirAll(filepath.Join(vault, "issues"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(vault, ".nd.yaml"), []byte("version: \"1\"\nprefix: T\ncreated_by: tester\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	oldVault := vaultDir
+	vaultDir = vault
+	defer func() { vaultDir = oldVault }()
+
+	issue := filepath.Join(vault, "issues", "T-abc.md")
+	tests := []struct {
+		line string
+		want string // substring of the problem; "" means allowed
+	}{
+		{`sed -i 's/open/closed/' ` + issue, "nd update T-abc"},
+		{`echo x >> ` + issue, "shell redirection"},
+		{`rm -f ` + issue, "nd delete T-abc"},
+		{`cat ` + issue + ` | grep status`, ""},
+		{`sed 's/a/b/' ` + issue + ` > /tmp/out.md`, ""},
+	}
+	for _, tt := range tests {
+		cmds, redirects := shellCommands(tt.line)
+		var writes []fileWrite
+		for _, r := range redirects {
+			writes = append(writes, fileWrite{Path: r, Via: ">"})
+		}
+		for _, words := range cmds {
+			writes = append(writes, fileWriteTargets(words)...)
+		}
+		got := vaultWriteProblem(writes)
+		if tt.want == "" && got != "" {
+			t.Errorf("%s: unexpected problem %q", tt.line, got)
+		}
+		if tt.want != "" && !strings.Contains(got, tt.want) {
+			t.Errorf("%s: problem = %q, want it to contain %q", tt.line, got, tt.want)
+		}
+	}
+
+	if got := vaultWriteProblem([]fileWrite{{Path: filepath.Join(vault, "issues", "T-new.md"), Via: "Write"}}); !strings.Contains(got, "nd create") {
+		t.Errorf("new file should point at nd create: %q", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(vault, ".nd.yaml"), []byte("version: \"1\"\nprefix: T\ncreated_by: tester\nguard_allow_vault_writes: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := vaultWriteProblem([]fileWrite{{Path: issue, Via: "Edit"}}); got != "" {
+		t.Errorf("guard.allow_vault_writes should allow the write: %q", got)
+	}
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/RamXX/nd/internal/store"
+)
+
+// fileWrite is a file a tool call would modify, and how.
+type fileWrite struct {
+	Path string
+	Via  string // tool name, shell command, or ">" for a redirection
+}
+
+// fileInput is the part of a Write, Edit, or MultiEdit payload nd guard reads.
+type fileInput struct {
+	FilePath string `json:"file_path"`
+}
+
+// fileWriteTargets returns the files a simple command modifies in place:
+// sed -i and perl -i edits, tee, cp/mv/install destinations, rm, and
+// truncate.
+func fileWriteTargets(words []string) []fileWrite {
+	for len(words) > 0 && (envAssignmentRe.MatchString(words[0]) || commandWrappers[words[0]]) {
+		words = words[1:]
+	}
+	if len(words) < 2 {
+		return nil
+	}
+	name := filepath.Base(words[0])
+	var operands []string
+	inPlace := false
+	for _, w := range words[1:] {
+		switch {
+		case w == "--in-place" || strings.HasPrefix(w, "--in-place="):
+			inPlace = true
+		case strings.HasPrefix(w, "-") && !strings.HasPrefix(w, "--") && len(w) > 1:
+			inPlace = inPlace || strings.Contains(w, "i")
+		case !strings.HasPrefix(w, "-"):
+			operands = append(operands, w)
+		}
+	}
+
+	var paths []string
+	switch name {
+	case "sed", "perl":
+		if inPlace {
+			paths = operands
+		}
+	case "tee", "rm", "truncate":
+		paths = operands
+	case "cp", "mv", "install":
+		if len(operands) > 1 {
+			paths = operands[len(operands)-1:]
+		}
+	}
+	var out []fileWrite
+	for _, p := range paths {
+		out = append(out, fileWrite{Path: p, Via: name})
+	}
+	return out
+}
+
+// vaultWriteProblem reports the first write that lands in the resolved
+// vault's issues/ directory, with the nd command to use instead. It returns
+// "" when there is none, or when guard.allow_vault_writes is set.
+func vaultWriteProblem(writes []fileWrite) string {
+	if len(writes) == 0 {
+		return ""
+	}
+	vault, err := filepath.Abs(resolveVaultDir())
+	if err != nil {
+		return ""
+	}
+	issuesDir := realPath(filepath.Join(vault, "issues"))
+	if info, err := os.Stat(issuesDir); err != nil || !info.IsDir() {
+		return ""
+	}
+
+	for _, w := range writes {
+		target := expandHome(w.Path)
+		if strings.Contains(target, "$") {
+			continue
+		}
+		if !filepath.IsAbs(target) {
+			cwd, err := os.Getwd()
+			if err != nil {
+				continue
+			}
+			target = filepath.Join(cwd, target)
+		}
+		target = realPath(target)
+		rel, err := filepath.Rel(issuesDir, target)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		if cfg, err := store.ReadConfig(vault); err == nil && cfg.GuardAllowVaultWrites {
+			return ""
+		}
+		id := strings.TrimSuffix(filepath.Base(target), ".md")
+		what := w.Via + " of " + w.Path
+		if w.Via == ">" {
+			what = "shell redirection to " + w.Path
+		}
+		return "blocked " + what + ". Hand edits to issue files break content_hash, the Links section, and bidirectional dependency edges. " +
+			vaultWriteHint(w.Via, id, target) +
+			"\nTo allow direct writes to issue files, run: nd config set guard.allow_vault_writes true"
+	}
+	return ""
+}
+
+// vaultWriteHint names the nd command that does what a direct write was after.
+func vaultWriteHint(via, id, target string) string {
+	if !strings.HasSuffix(target, ".md") {
+		id = "<id>"
+	}
+	if via == "rm" {
+		return "Use nd delete " + id + ", which also removes references from other issues."
+	}
+	if _, err := os.Stat(target); err != nil && (via == "Write" || via == ">") {
+		return "Use nd create (or nd q) to add an issue."
+	}
+	return "Use nd update " + id + " (--title, --status, --description or --body-file, --append-notes, --add-label), nd comments add " + id + ", or nd dep add."
+}
+
+// realPath resolves symlinks in the longest existing prefix of path, so a
+// file that does not exist yet compares equal to its real directory.
+func realPath(path string) string {
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	dir, file := filepath.Split(path)
+	dir = filepath.Clean(dir)
+	if dir == path {
+		return path
+	}
+	return filepath.Join(realPath(dir), file)
+}
+
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -24,6 +24,11 @@ var labelsAddCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		issue, err := s.ReadIssue(id)
 		if err != nil {
 			return err
@@ -58,6 +63,11 @@ var labelsRmCmd = &cobra.Command{
 			return err
 		}
 		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		issue, err := s.ReadIssue(id)
 		if err != nil {
 			return err
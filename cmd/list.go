@@ -42,6 +42,12 @@ var listCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if opts.Parent != "" {
+			if opts.Parent, err = s.ResolveID(opts.Parent); err != nil {
+				return err
+			}
+		}
+
 		issues, err := s.ListIssues(opts)
 		if err != nil {
 			return err
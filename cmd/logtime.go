@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var logTimeCmd = &cobra.Command{
+	Use:   "log-time <id> <duration> [note]",
+	Short: "Log time spent on an issue",
+	Long:  "Adds the duration (e.g. 2h30m, 45m, 1.5d) to the issue's spent total and records a time entry in its History.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		d, err := model.ParseEffort(args[1])
+		if err != nil {
+			return usageError{err}
+		}
+		note := strings.Join(args[2:], " ")
+		by, _ := cmd.Flags().GetString("by")
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
+		if err := s.LogTime(id, d, by, note); err != nil {
+			return err
+		}
+		if !quiet {
+			issue, err := s.ReadIssue(id)
+			if err != nil {
+				return err
+			}
+			line := fmt.Sprintf("Logged %s on %s (spent %s", model.FormatEffort(d), id, issue.Spent)
+			if issue.Estimate != "" {
+				line += " of " + issue.Estimate
+			}
+			fmt.Println(line + ")")
+		}
+		return nil
+	},
+}
+
+func init() {
+	logTimeCmd.Flags().String("by", "", "who did the work (default: the issue's assignee, then config created_by)")
+	rootCmd.AddCommand(logTimeCmd)
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// nextCandidates is how many runners-up --explain and --json show.
+const nextCandidates = 5
+
+type recommendationJSON struct {
+	ID       string              `json:"id"`
+	Title    string              `json:"title"`
+	Priority string              `json:"priority"`
+	Assignee string              `json:"assignee,omitempty"`
+	Score    float64             `json:"score"`
+	Factors  []graph.ScoreFactor `json:"factors"`
+}
+
+type nextJSON struct {
+	Actor      string               `json:"actor"`
+	LastClosed string               `json:"last_closed,omitempty"`
+	Pick       *recommendationJSON  `json:"pick"`
+	Candidates []recommendationJSON `json:"candidates"`
+}
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Recommend the ready issue to work on next",
+	Long: `Scores ready issues and recommends the best one. The score adds up:
+
+  priority     10 points per level above P4
+  age          0.5 per day open, up to 10
+  due          30 when overdue, 15 when due within 3 days
+  unblocks     4 per open issue transitively waiting on it, up to 20
+  momentum     up to 10 as the parent epic nears completion
+  continuity   12 when your last closed issue unblocked it, 8 when it
+               shares that issue's parent
+
+Candidates are ready issues that are not epics, not already in progress,
+and not assigned to someone else. --assignee narrows them to one person's
+issues ("me" is the actor: $ND_ACTOR, then config created_by).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		assignee, _ := cmd.Flags().GetString("assignee")
+		explain, _ := cmd.Flags().GetBool("explain")
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		if _, err := wakeDue(s); err != nil {
+			return err
+		}
+		all, err := s.ListIssues(store.FilterOptions{})
+		if err != nil {
+			return err
+		}
+
+		actor := resolveActor(cmd, s)
+		if assignee == "me" {
+			assignee = actor
+		}
+		var candidates []*model.Issue
+		g := graph.Build(all)
+		for _, issue := range g.Ready() {
+			if issue.Type == model.TypeEpic || issue.Status == model.StatusInProgress || issue.Status == model.StatusBlocked {
+				continue
+			}
+			if assignee != "" && issue.Assignee != assignee {
+				continue
+			}
+			if assignee == "" && issue.Assignee != "" && issue.Assignee != actor {
+				continue
+			}
+			candidates = append(candidates, issue)
+		}
+
+		last := lastClosedBy(all, actor)
+		recs := g.Recommend(candidates, graph.NextOptions{Now: time.Now(), LastClosed: last})
+		if len(recs) > nextCandidates {
+			recs = recs[:nextCandidates]
+		}
+
+		if jsonOut {
+			out := nextJSON{Actor: actor, Candidates: []recommendationJSON{}}
+			if last != nil {
+				out.LastClosed = last.ID
+			}
+			for _, r := range recs {
+				out.Candidates = append(out.Candidates, recommendationToJSON(r))
+			}
+			if len(out.Candidates) > 0 {
+				out.Pick = &out.Candidates[0]
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+
+		if len(recs) == 0 {
+			fmt.Println("Nothing ready to pick up.")
+			return nil
+		}
+		top := recs[0]
+		fmt.Printf("Next: %s %s (%s, score %.1f)\n", top.Issue.ID, top.Issue.Title, top.Issue.Priority.Short(), top.Score)
+		if !explain {
+			var why []string
+			for _, f := range top.Factors {
+				why = append(why, f.Detail)
+			}
+			if len(why) > 0 {
+				fmt.Printf("  Why: %s\n", strings.Join(why, "; "))
+			}
+			return nil
+		}
+		printFactors(top)
+		if len(recs) > 1 {
+			fmt.Println()
+			fmt.Println("Runners-up:")
+			for _, r := range recs[1:] {
+				fmt.Printf("  %s %s (%s, score %.1f)\n", r.Issue.ID, r.Issue.Title, r.Issue.Priority.Short(), r.Score)
+				printFactors(r)
+			}
+		}
+		return nil
+	},
+}
+
+func printFactors(r graph.Recommendation) {
+	for _, f := range r.Factors {
+		fmt.Printf("    %+6.1f  %-11s %s\n", f.Points, f.Name, f.Detail)
+	}
+}
+
+func recommendationToJSON(r graph.Recommendation) recommendationJSON {
+	factors := r.Factors
+	if factors == nil {
+		factors = []graph.ScoreFactor{}
+	}
+	return recommendationJSON{
+		ID:       r.Issue.ID,
+		Title:    r.Issue.Title,
+		Priority: r.Issue.Priority.Short(),
+		Assignee: r.Issue.Assignee,
+		Score:    r.Score,
+		Factors:  factors,
+	}
+}
+
+// lastClosedBy returns the actor's most recently closed issue, or nil.
+func lastClosedBy(issues []*model.Issue, actor string) *model.Issue {
+	var last *model.Issue
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed || issue.Assignee != actor || issue.ClosedAt == "" {
+			continue
+		}
+		if last == nil || issue.ClosedAt > last.ClosedAt {
+			last = issue
+		}
+	}
+	return last
+}
+
+func init() {
+	nextCmd.Flags().String("assignee", "", `only issues assigned to this person ("me" for the actor)`)
+	nextCmd.Flags().Bool("explain", false, "show the score breakdown and runners-up")
+	rootCmd.AddCommand(nextCmd)
+}
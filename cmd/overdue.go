@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var overdueCmd = &cobra.Command{
+	Use:   "overdue",
+	Short: "List open issues past their due date",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		soon, _ := cmd.Flags().GetBool("soon")
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		issues, err := s.OverdueIssues(time.Now(), soon)
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			return format.JSON(os.Stdout, issues)
+		}
+		if len(issues) == 0 {
+			if soon {
+				fmt.Println("Nothing is overdue or due soon.")
+			} else {
+				fmt.Println("Nothing is overdue.")
+			}
+			return nil
+		}
+		format.Table(os.Stdout, issues)
+		return nil
+	},
+}
+
+func init() {
+	overdueCmd.Flags().Bool("soon", false, "also list issues due within the next 3 days")
+	rootCmd.AddCommand(overdueCmd)
+}
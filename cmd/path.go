@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/RamXX/nd/internal/format"
 	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -29,16 +31,34 @@ var pathCmd = &cobra.Command{
 		g := graph.Build(all)
 
 		if len(args) == 1 {
-			id := args[0]
+			id, err := s.ResolveID(args[0])
+			if err != nil {
+				return err
+			}
 			tree := g.ExecutionPath(id)
 			if tree == nil {
-				return fmt.Errorf("issue %s not found", id)
+				return s.NotFound(id)
+			}
+			if jsonOut {
+				var issues []*model.Issue
+				root := pathNodeJSON(tree, &issues)
+				return format.JSONTree(os.Stdout, issues, []format.NodeJSON{root})
 			}
 			printPathNode(tree, "", true)
 			return nil
 		}
 
 		roots := g.PathRoots()
+		if jsonOut {
+			var issues []*model.Issue
+			var nodes []format.NodeJSON
+			for _, root := range roots {
+				if tree := g.ExecutionPath(root.ID); tree != nil {
+					nodes = append(nodes, pathNodeJSON(tree, &issues))
+				}
+			}
+			return format.JSONTree(os.Stdout, issues, nodes)
+		}
 		if len(roots) == 0 {
 			fmt.Println("No execution paths to display.")
 			return nil
@@ -85,6 +105,17 @@ func printPathNode(node *graph.PathNode, prefix string, isLast bool) {
 	}
 }
 
+// pathNodeJSON converts an execution path for JSON output, collecting its
+// issues.
+func pathNodeJSON(node *graph.PathNode, issues *[]*model.Issue) format.NodeJSON {
+	*issues = append(*issues, node.Issue)
+	out := format.NodeJSON{ID: node.Issue.ID}
+	for _, child := range node.Children {
+		out.Children = append(out.Children, pathNodeJSON(child, issues))
+	}
+	return out
+}
+
 func init() {
 	rootCmd.AddCommand(pathCmd)
 }
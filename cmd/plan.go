@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+type planItemJSON struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Priority string   `json:"priority"`
+	Parent   string   `json:"parent,omitempty"`
+	Labels   []string `json:"labels,omitempty"`
+	Lane     int      `json:"lane"`
+	Wave     int      `json:"wave"`
+}
+
+type planHeldJSON struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+type planJSON struct {
+	Scope string           `json:"scope,omitempty"`
+	Lanes [][]planItemJSON `json:"lanes"`
+	Waves [][]planItemJSON `json:"waves"`
+	Held  []planHeldJSON   `json:"held"`
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan [epic]",
+	Short: "Split open work into parallel lanes for several agents",
+	Long: `Schedules the open subgraph (optionally an epic's descendants) into
+--lanes parallel lanes of ordered work, grouped into waves that can run at
+the same time. An issue enters a wave once all of its blockers are closed or
+in an earlier wave. Higher priorities go first, and issues sharing a parent
+or label stay in the same lane to reduce merge conflicts.
+
+Deferred issues, issues waiting on open work outside the scope, and their
+dependents are listed as held. Epics are containers and are not scheduled.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		lanes, _ := cmd.Flags().GetInt("lanes")
+		if lanes < 1 {
+			return fmt.Errorf("--lanes must be at least 1")
+		}
+
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		all, err := s.ListIssues(store.FilterOptions{})
+		if err != nil {
+			return err
+		}
+		g := graph.Build(all)
+
+		var scope map[string]bool
+		scopeID := ""
+		if len(args) == 1 {
+			if scopeID, err = s.ResolveID(args[0]); err != nil {
+				return err
+			}
+			if scope = g.Descendants(scopeID); scope == nil {
+				return s.NotFound(scopeID)
+			}
+		}
+
+		plan, err := g.Plan(scope, lanes)
+		if err != nil {
+			return err
+		}
+
+		if jsonOut {
+			out := planJSON{Scope: scopeID, Lanes: [][]planItemJSON{}, Waves: [][]planItemJSON{}, Held: []planHeldJSON{}}
+			for _, lane := range plan.Lanes {
+				row := []planItemJSON{}
+				for _, it := range lane {
+					row = append(row, planItemToJSON(it))
+				}
+				out.Lanes = append(out.Lanes, row)
+			}
+			for _, wave := range plan.Waves {
+				row := []planItemJSON{}
+				for _, it := range wave {
+					row = append(row, planItemToJSON(it))
+				}
+				out.Waves = append(out.Waves, row)
+			}
+			for _, h := range plan.Held {
+				out.Held = append(out.Held, planHeldJSON{ID: h.Issue.ID, Title: h.Issue.Title, Reason: h.Reason})
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}
+
+		if len(plan.Waves) == 0 && len(plan.Held) == 0 {
+			fmt.Println("No open work to plan.")
+			return nil
+		}
+
+		for i, wave := range plan.Waves {
+			fmt.Printf("Wave %d:\n", i+1)
+			for _, it := range wave {
+				fmt.Printf("  lane %d: %s %s (%s)\n", it.Lane+1, it.Issue.ID, it.Issue.Title, it.Issue.Priority.Short())
+			}
+		}
+
+		fmt.Println()
+		fmt.Println("Lanes:")
+		for i, lane := range plan.Lanes {
+			ids := make([]string, len(lane))
+			for j, it := range lane {
+				ids[j] = it.Issue.ID
+			}
+			if len(ids) == 0 {
+				fmt.Printf("  lane %d: (idle)\n", i+1)
+				continue
+			}
+			fmt.Printf("  lane %d: %s\n", i+1, strings.Join(ids, " -> "))
+		}
+
+		if len(plan.Held) > 0 {
+			fmt.Println()
+			fmt.Println("Held:")
+			for _, h := range plan.Held {
+				fmt.Printf("  %s %s (%s)\n", h.Issue.ID, h.Issue.Title, h.Reason)
+			}
+		}
+		return nil
+	},
+}
+
+func planItemToJSON(it *graph.PlanItem) planItemJSON {
+	return planItemJSON{
+		ID:       it.Issue.ID,
+		Title:    it.Issue.Title,
+		Priority: it.Issue.Priority.Short(),
+		Parent:   it.Issue.Parent,
+		Labels:   it.Issue.Labels,
+		Lane:     it.Lane + 1,
+		Wave:     it.Wave + 1,
+	}
+}
+
+func init() {
+	planCmd.Flags().Int("lanes", 1, "number of parallel lanes (agents)")
+	rootCmd.AddCommand(planCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"os"
+	"time"
 
 	"github.com/RamXX/nd/internal/format"
 	"github.com/RamXX/nd/internal/graph"
@@ -13,13 +14,50 @@ import (
 var primeCmd = &cobra.Command{
 	Use:   "prime",
 	Short: "Output AI context summary",
+	Long: `Outputs a structured project summary for AI context injection. A profile
+picks the sections and limits; configure one with
+nd config set prime.<profile>.sections <list>. Sections:
+
+  summary          totals, overdue and due-soon counts
+  changed          issues updated since the actor's last prime
+  overdue          overdue and due-soon issues
+  ready            ready work, by priority
+  in_progress      work in progress
+  blocked          issues waiting on open blockers
+  recently_closed  issues closed in the last closed_days, with reasons
+  epics            open epics with child progress
+  stale            open issues not updated in stale_days
+  rules            the workflow rules in plain English
+
+Without --profile the default profile is used (summary, overdue, ready,
+blocked, in_progress unless prime.default is configured). Each run records
+the time per actor ($ND_ACTOR, then config created_by, or --as) so the next
+run can report what changed; --no-record leaves it untouched.
+
+--json emits the same report; --full adds every issue and the unfiltered
+ready, blocked, overdue, and due-soon lists.`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		profileName, _ := cmd.Flags().GetString("profile")
+		noRecord, _ := cmd.Flags().GetBool("no-record")
+		full, _ := cmd.Flags().GetBool("full")
+
 		s, err := store.Open(resolveVaultDir())
 		if err != nil {
 			return err
 		}
 		defer s.Close()
 
+		profile, err := s.PrimeProfile(profileName)
+		if err != nil {
+			return err
+		}
+
+		// Deferred issues whose date has passed become actionable again.
+		if _, err := wakeDue(s); err != nil {
+			return err
+		}
+
 		all, err := s.ListIssues(store.FilterOptions{})
 		if err != nil {
 			return err
@@ -29,23 +67,83 @@ var primeCmd = &cobra.Command{
 		ready := g.Ready()
 		blocked := g.Blocked()
 
+		now := time.Now()
+		actor := resolveActor(cmd, s)
+		since, _ := s.LastPrime(actor)
+		report := format.BuildPrime(all, ready, blocked, format.PrimeOptions{
+			Sections:   profile.Sections,
+			Limit:      profile.Limit,
+			Actor:      actor,
+			Mine:       profile.Mine,
+			Since:      since,
+			ClosedDays: profile.ClosedDays,
+			StaleDays:  profile.StaleDays,
+			Rules:      s.RulesSummary(),
+			Now:        now,
+		})
+
 		if jsonOut {
-			data := map[string]any{
-				"total":   len(all),
-				"ready":   ready,
-				"blocked": blocked,
-				"issues":  all,
+			out := primeJSON{
+				SchemaVersion: format.JSONSchemaVersion,
+				Profile:       profileNameOrDefault(profileName),
+				PrimeReport:   report,
+			}
+			if full {
+				overdue, dueSoon := format.DueBuckets(all, now)
+				out.primeFullJSON = &primeFullJSON{
+					Total:   len(all),
+					Ready:   format.ToJSONList(ready),
+					Blocked: format.ToJSONList(blocked),
+					Overdue: format.ToJSONList(overdue),
+					DueSoon: format.ToJSONList(dueSoon),
+					Issues:  format.ToJSONList(all),
+				}
 			}
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			return enc.Encode(data)
+			if err := enc.Encode(out); err != nil {
+				return err
+			}
+		} else {
+			format.WritePrime(os.Stdout, report)
 		}
 
-		format.PrimeContext(os.Stdout, all, ready, blocked)
-		return nil
+		if noRecord {
+			return nil
+		}
+		return s.RecordPrime(actor, now)
 	},
 }
 
+// primeJSON is the --json output of nd prime: the profile's report, plus
+// the unfiltered issue lists with --full.
+type primeJSON struct {
+	SchemaVersion int    `json:"schema_version"`
+	Profile       string `json:"profile"`
+	*format.PrimeReport
+	*primeFullJSON
+}
+
+type primeFullJSON struct {
+	Total   int                `json:"total"`
+	Ready   []format.IssueJSON `json:"ready"`
+	Blocked []format.IssueJSON `json:"blocked"`
+	Overdue []format.IssueJSON `json:"overdue"`
+	DueSoon []format.IssueJSON `json:"due_soon"`
+	Issues  []format.IssueJSON `json:"issues"`
+}
+
+func profileNameOrDefault(name string) string {
+	if name == "" {
+		return store.DefaultPrimeProfile
+	}
+	return name
+}
+
 func init() {
+	primeCmd.Flags().String("profile", "", "prime profile from config (default: default)")
+	primeCmd.Flags().String("as", "", "actor whose last prime is compared and recorded (default: $ND_ACTOR, then config created_by)")
+	primeCmd.Flags().Bool("full", false, "with --json, also include every issue and the full ready, blocked, overdue, and due-soon lists")
+	primeCmd.Flags().Bool("no-record", false, "do not record this run as the actor's last prime")
 	rootCmd.AddCommand(primeCmd)
 }
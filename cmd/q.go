@@ -61,7 +61,18 @@ var qCmd = &cobra.Command{
 		}
 		defer s.Close()
 
-		issue, err := s.CreateIssue(title, description, issueType, priority, assignee, labels, parent)
+		if parent != "" {
+			if parent, err = s.ResolveID(parent); err != nil {
+				return err
+			}
+		}
+
+		tmpl, err := resolveTemplate(cmd, s, &issueType, &priority, &assignee, &labels)
+		if err != nil {
+			return err
+		}
+
+		issue, err := s.CreateIssue(title, description, issueType, priority, assignee, labels, parent, store.CreateOptions{Template: tmpl})
 		if err != nil {
 			return err
 		}
@@ -85,5 +96,6 @@ func init() {
 	qCmd.Flags().StringP("description", "d", "", "issue description")
 	qCmd.Flags().String("parent", "", "parent issue ID")
 	qCmd.Flags().String("body-file", "", "read description from file (- for stdin)")
+	qCmd.Flags().String("template", "", "body template name")
 	rootCmd.AddCommand(qCmd)
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/RamXX/nd/internal/format"
@@ -40,6 +41,17 @@ Supports the same filter flags as 'nd list' for scoping results
 		}
 		defer s.Close()
 
+		if opts.Parent != "" {
+			if opts.Parent, err = s.ResolveID(opts.Parent); err != nil {
+				return err
+			}
+		}
+
+		// Deferred issues whose date has passed become actionable again.
+		if _, err := wakeDue(s); err != nil {
+			return err
+		}
+
 		// Load all issues in the vault (unfiltered) for accurate graph
 		// computation -- blockers may live outside the filtered set.
 		all, err := s.ListIssues(store.FilterOptions{})
@@ -63,6 +75,22 @@ Supports the same filter flags as 'nd list' for scoping results
 			return format.JSON(os.Stdout, ready)
 		}
 		format.Table(os.Stdout, ready)
+
+		// Remaining capacity: status-wide limits, plus the assignee's own
+		// limits when scoped with --assignee.
+		var capacity []store.WIPUsage
+		for _, u := range s.WIPCapacity(all) {
+			if u.Assignee == "" {
+				capacity = append(capacity, u)
+			}
+		}
+		if opts.Assignee != "" {
+			capacity = append(capacity, s.AssigneeCapacity(all, opts.Assignee)...)
+		}
+		if len(capacity) > 0 && !quiet {
+			fmt.Println("\nWIP Capacity:")
+			printCapacity(capacity)
+		}
 		return nil
 	},
 }
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/format"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var recurringCmd = &cobra.Command{
+	Use:   "recurring",
+	Short: "Inspect recurring issues",
+}
+
+var recurringListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the schedule of open recurring issues",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		issues, err := s.RecurringIssues()
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return format.JSON(os.Stdout, issues)
+		}
+		if len(issues) == 0 {
+			fmt.Println("No recurring issues.")
+			return nil
+		}
+
+		now := time.Now()
+		fmt.Printf("%-12s %-14s %-22s %s\n", "ID", "RULE", "NEXT", "TITLE")
+		for _, issue := range issues {
+			// An instance that is not deferred is the one being worked on now.
+			next := string(issue.Status)
+			if issue.Status == model.StatusDeferred {
+				next = "due now"
+				if issue.DeferUntil != "" && !dates.Reached(issue.DeferUntil, now) {
+					next = issue.DeferUntil
+				}
+			}
+			fmt.Printf("%-12s %-14s %-22s %s\n", issue.ID, issue.Recur, next, issue.Title)
+		}
+		return nil
+	},
+}
+
+func init() {
+	recurringCmd.AddCommand(recurringListCmd)
+	rootCmd.AddCommand(recurringCmd)
+}
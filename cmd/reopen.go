@@ -19,6 +19,10 @@ var reopenCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		if err := s.ReopenIssue(id); err != nil {
 			return err
 		}
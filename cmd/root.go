@@ -19,16 +19,18 @@ var (
 )
 
 var rootCmd = &cobra.Command{
-	Use:          "nd",
-	Short:        "Vault-backed issue tracker",
-	Long:         "nd -- Git-native issue tracking with Obsidian-compatible markdown files.",
-	Version:      version,
-	SilenceUsage: true,
+	Use:           "nd",
+	Short:         "Vault-backed issue tracker",
+	Long:          "nd -- Git-native issue tracking with Obsidian-compatible markdown files.",
+	Version:       version,
+	SilenceUsage:  true,
+	SilenceErrors: true, // reported by Execute, as JSON with --json
 }
 
 func Execute() {
+	markUsageErrors(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(reportError(err))
 	}
 }
 
@@ -37,6 +39,9 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&jsonOut, "json", false, "output as JSON")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&quiet, "quiet", false, "suppress non-essential output")
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err}
+	})
 }
 
 const sharedVaultConfigRelPath = ".vault/.nd-shared.yaml"
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// argProbeLimit is the largest argument count probed when working out what
+// a command's Args validator accepts; accepting it means "no maximum".
+const argProbeLimit = 16
+
+type flagSchema struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default"`
+	Usage     string `json:"usage"`
+}
+
+type positionalSchema struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Variadic bool   `json:"variadic,omitempty"`
+}
+
+type argsSchema struct {
+	Min        int                `json:"min"`
+	Max        int                `json:"max"` // -1 means no maximum
+	Positional []positionalSchema `json:"positional"`
+}
+
+type commandSchema struct {
+	Name        string          `json:"name"`
+	Path        string          `json:"path"`
+	Usage       string          `json:"usage"`
+	Aliases     []string        `json:"aliases,omitempty"`
+	Short       string          `json:"short"`
+	Long        string          `json:"long,omitempty"`
+	Example     string          `json:"example,omitempty"`
+	Hidden      bool            `json:"hidden,omitempty"`
+	Runnable    bool            `json:"runnable"`
+	Args        argsSchema      `json:"args"`
+	Flags       []flagSchema    `json:"flags"`
+	Subcommands []commandSchema `json:"subcommands,omitempty"`
+}
+
+type cliSchema struct {
+	Version     string          `json:"version"`
+	GlobalFlags []flagSchema    `json:"global_flags"`
+	Commands    []commandSchema `json:"commands"`
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Export machine-readable schemas of the CLI and issue files",
+	Long: `Exports schemas generated from nd itself, so skills, hooks, and completion
+tooling can be generated instead of hand-written.`,
+}
+
+var schemaCommandsCmd = &cobra.Command{
+	Use:   "commands",
+	Short: "Describe every command, alias, flag, and positional argument",
+	Long: `Walks the command tree and describes every command: its aliases, flags
+(type, default, shorthand), positional arguments, and the minimum and
+maximum argument counts its validator accepts (max -1 means no limit).
+Hidden commands are included and marked. Global flags are listed once.
+Without --json, prints one usage line per command.`,
+	Example: `  nd schema commands --json | jq '.commands[] | select(.name == "update") | .flags[].name'`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema := cliSchema{Version: rootCmd.Version, GlobalFlags: flagSchemas(rootCmd.PersistentFlags())}
+		for _, child := range rootCmd.Commands() {
+			schema.Commands = append(schema.Commands, describeCommand(child))
+		}
+
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(schema)
+		}
+		var printTree func(cs []commandSchema)
+		printTree = func(cs []commandSchema) {
+			for _, c := range cs {
+				if c.Hidden {
+					continue
+				}
+				var flags []string
+				for _, f := range c.Flags {
+					flags = append(flags, "--"+f.Name)
+				}
+				fmt.Println(strings.TrimRight(fmt.Sprintf("%-40s %s", c.Usage, strings.Join(flags, " ")), " "))
+				printTree(c.Subcommands)
+			}
+		}
+		printTree(schema.Commands)
+		return nil
+	},
+}
+
+var schemaIssueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Print the JSON Schema of issue frontmatter",
+	Long: `Prints a JSON Schema (draft 2020-12) for the YAML frontmatter of issue
+files. Inside a vault, its custom statuses are included in the status enum.
+The output is always JSON.`,
+	Example: `  nd schema issue > issue.schema.json`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var custom []model.Status
+		if cfg, err := store.ReadConfig(resolveVaultDir()); err == nil {
+			custom = cfg.CustomStatuses()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(model.IssueSchema(custom))
+	},
+}
+
+func describeCommand(c *cobra.Command) commandSchema {
+	cs := commandSchema{
+		Name:     c.Name(),
+		Path:     c.CommandPath(),
+		Usage:    c.UseLine(),
+		Aliases:  c.Aliases,
+		Short:    c.Short,
+		Long:     c.Long,
+		Example:  c.Example,
+		Hidden:   c.Hidden,
+		Runnable: c.Runnable(),
+		Args:     describeArgs(c),
+		Flags:    flagSchemas(c.LocalFlags()),
+	}
+	for _, child := range c.Commands() {
+		cs.Subcommands = append(cs.Subcommands, describeCommand(child))
+	}
+	return cs
+}
+
+// describeArgs reads positional names from the Use line (<required>,
+// [optional], trailing ... for variadic) and finds the accepted counts by
+// probing the Args validator.
+func describeArgs(c *cobra.Command) argsSchema {
+	as := argsSchema{Positional: []positionalSchema{}}
+	for _, tok := range strings.Fields(c.Use)[1:] {
+		p := positionalSchema{Required: strings.HasPrefix(tok, "<")}
+		tok = strings.Trim(tok, "<>[]")
+		p.Variadic = strings.HasSuffix(tok, "...")
+		p.Name = strings.Trim(strings.TrimSuffix(tok, "..."), "<>[]")
+		as.Positional = append(as.Positional, p)
+	}
+
+	switch {
+	case c.Args == nil && c.HasSubCommands():
+		return as
+	case c.Args == nil:
+		as.Max = -1
+		return as
+	}
+	as.Min, as.Max = -1, 0
+	for n := 0; n <= argProbeLimit; n++ {
+		if c.Args(c, make([]string, n)) != nil {
+			continue
+		}
+		if as.Min < 0 {
+			as.Min = n
+		}
+		as.Max = n
+	}
+	if as.Min < 0 {
+		as.Min = 0
+	}
+	if as.Max == argProbeLimit {
+		as.Max = -1
+	}
+	return as
+}
+
+func flagSchemas(fs *pflag.FlagSet) []flagSchema {
+	out := []flagSchema{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		out = append(out, flagSchema{
+			Name:      f.Name,
+			Shorthand: f.Shorthand,
+			Type:      f.Value.Type(),
+			Default:   f.DefValue,
+			Usage:     f.Usage,
+		})
+	})
+	return out
+}
+
+func init() {
+	schemaCmd.AddCommand(schemaCommandsCmd)
+	schemaCmd.AddCommand(schemaIssueCmd)
+	rootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestDescribeArgs(t *testing.T) {
+	tests := []struct {
+		path     []string
+		min, max int
+		names    string
+	}{
+		{[]string{"update"}, 0, 1, "id"},
+		{[]string{"close"}, 1, -1, "id,id..."},
+		{[]string{"claim"}, 0, 1, "id"},
+		{[]string{"dep", "add"}, 2, 2, "issue,depends-on"},
+		{[]string{"epic"}, 0, 0, ""},
+	}
+	for _, tt := range tests {
+		c, _, err := rootCmd.Find(tt.path)
+		if err != nil {
+			t.Fatalf("find %v: %v", tt.path, err)
+		}
+		as := describeArgs(c)
+		var names string
+		for i, p := range as.Positional {
+			if i > 0 {
+				names += ","
+			}
+			names += p.Name
+			if p.Variadic {
+				names += "..."
+			}
+		}
+		if as.Min != tt.min || as.Max != tt.max || names != tt.names {
+			t.Errorf("%v: min=%d max=%d names=%q, want %d %d %q", tt.path, as.Min, as.Max, names, tt.min, tt.max, tt.names)
+		}
+	}
+}
+
+func TestDescribeCommandFlags(t *testing.T) {
+	c, _, _ := rootCmd.Find([]string{"update"})
+	cs := describeCommand(c)
+	byName := make(map[string]flagSchema)
+	for _, f := range cs.Flags {
+		byName[f.Name] = f
+	}
+	if f := byName["description"]; f.Shorthand != "d" || f.Type != "string" {
+		t.Errorf("description flag = %+v", f)
+	}
+	if _, ok := byName["json"]; ok {
+		t.Error("global flags belong in global_flags, not on each command")
+	}
+	if _, ok := byName["help"]; ok {
+		t.Error("help flag should be left out")
+	}
+}
+
+func TestIssueSchemaIncludesCustomStatuses(t *testing.T) {
+	schema := model.IssueSchema([]model.Status{"review"})
+	props := schema["properties"].(map[string]any)
+	enum := props["status"].(map[string]any)["enum"].([]string)
+	if enum[len(enum)-1] != "review" {
+		t.Errorf("status enum = %v", enum)
+	}
+}
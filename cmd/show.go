@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/RamXX/nd/internal/format"
@@ -23,9 +22,13 @@ var showCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
 		issue, err := s.ReadIssue(id)
 		if err != nil {
-			return fmt.Errorf("issue %s not found: %w", id, err)
+			return err
 		}
 
 		if jsonOut {
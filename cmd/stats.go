@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
@@ -26,13 +28,27 @@ var statsCmd = &cobra.Command{
 			return err
 		}
 
+		by, _ := cmd.Flags().GetString("by")
+		switch by {
+		case "":
+		case "assignee":
+			return printTimeByAssignee(cmd, all)
+		default:
+			return fmt.Errorf("unknown --by %q: must be assignee", by)
+		}
+
 		g := graph.Build(all)
 		st := g.Stats()
 
+		capacity := s.WIPCapacity(all)
+
 		if jsonOut {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			return enc.Encode(st)
+			return enc.Encode(struct {
+				graph.Stats
+				Capacity []store.WIPUsage `json:"capacity,omitempty"`
+			}{st, capacity})
 		}
 
 		fmt.Printf("Total:       %d\n", st.Total)
@@ -71,10 +87,78 @@ var statsCmd = &cobra.Command{
 				}
 			}
 		}
+		if len(capacity) > 0 {
+			fmt.Println("\nWIP Capacity:")
+			printCapacity(capacity)
+		}
 		return nil
 	},
 }
 
+// printCapacity prints one line per WIP slot: used/limit and free slots.
+func printCapacity(usage []store.WIPUsage) {
+	for _, u := range usage {
+		scope := string(u.Status)
+		if u.Assignee != "" {
+			scope += " @" + u.Assignee
+		}
+		free := fmt.Sprintf("%d free", u.Remaining())
+		if u.Remaining() == 0 {
+			free = "full"
+		}
+		fmt.Printf("  %-24s %d/%d  (%s)\n", scope, u.Used(), u.Limit, free)
+	}
+}
+
+// printTimeByAssignee reports time logged with nd log-time per person,
+// limited to the --since/--until window.
+func printTimeByAssignee(cmd *cobra.Command, all []*model.Issue) error {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+	since, err := parseDate(sinceStr, false)
+	if err != nil {
+		return fmt.Errorf("invalid --since date: %w", err)
+	}
+	until, err := parseDate(untilStr, true)
+	if err != nil {
+		return fmt.Errorf("invalid --until date: %w", err)
+	}
+	reports := store.TimeByAuthor(all, since, until)
+
+	if jsonOut {
+		type row struct {
+			Assignee string   `json:"assignee"`
+			Logged   string   `json:"logged"`
+			Minutes  int      `json:"minutes"`
+			Entries  int      `json:"entries"`
+			Issues   []string `json:"issues"`
+		}
+		rows := make([]row, len(reports))
+		for i, r := range reports {
+			rows[i] = row{r.Author, model.FormatEffort(r.Total), int(r.Total.Minutes()), r.Entries, r.Issues}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("No time logged in this range.")
+		return nil
+	}
+	var total time.Duration
+	fmt.Printf("%-16s %9s %8s %7s\n", "ASSIGNEE", "LOGGED", "ENTRIES", "ISSUES")
+	for _, r := range reports {
+		fmt.Printf("%-16s %9s %8d %7d\n", r.Author, model.FormatEffort(r.Total), r.Entries, len(r.Issues))
+		total += r.Total
+	}
+	fmt.Printf("%-16s %9s\n", "Total", model.FormatEffort(total))
+	return nil
+}
+
 func init() {
+	statsCmd.Flags().String("by", "", "group logged time by: assignee")
+	statsCmd.Flags().String("since", "", "with --by: count time logged on or after this date (YYYY-MM-DD, -1w, ...)")
+	statsCmd.Flags().String("until", "", "with --by: count time logged on or before this date")
 	rootCmd.AddCommand(statsCmd)
 }
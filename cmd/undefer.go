@@ -2,17 +2,24 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
 
 var undeferCmd = &cobra.Command{
-	Use:   "undefer <id>",
+	Use:   "undefer [id]",
 	Short: "Restore a deferred issue to its configured resume status",
-	Args:  cobra.ExactArgs(1),
+	Long:  "Restore one deferred issue, or with --due every deferred issue whose defer_until date has passed.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id := args[0]
+		due, _ := cmd.Flags().GetBool("due")
+		if due == (len(args) == 1) {
+			return fmt.Errorf("pass either an issue ID or --due")
+		}
 
 		s, err := store.Open(resolveVaultDir())
 		if err != nil {
@@ -20,6 +27,21 @@ var undeferCmd = &cobra.Command{
 		}
 		defer s.Close()
 
+		if due {
+			woken, err := wakeDue(s)
+			if err != nil {
+				return err
+			}
+			if !quiet && len(woken) == 0 {
+				fmt.Println("No deferred issues are due.")
+			}
+			return nil
+		}
+		id, err := s.ResolveID(args[0])
+		if err != nil {
+			return err
+		}
+
 		if err := s.UnDeferIssue(id); err != nil {
 			return err
 		}
@@ -31,6 +53,31 @@ var undeferCmd = &cobra.Command{
 	},
 }
 
+// wakeDue undefers every deferred issue whose defer_until has passed and
+// reports each wake-up (or refusal) on stderr, keeping stdout clean for
+// the calling command's own output.
+func wakeDue(s *store.Store) ([]string, error) {
+	woken, failed, err := s.WakeDueIssues(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !quiet {
+		for _, id := range woken {
+			fmt.Fprintf(os.Stderr, "Woke %s (defer_until reached)\n", id)
+		}
+		ids := make([]string, 0, len(failed))
+		for id := range failed {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintf(os.Stderr, "Could not wake %s: %v\n", id, failed[id])
+		}
+	}
+	return woken, nil
+}
+
 func init() {
+	undeferCmd.Flags().Bool("due", false, "undefer every issue whose defer_until date has passed")
 	rootCmd.AddCommand(undeferCmd)
 }
@@ -1,224 +1,595 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/format"
 	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/store"
 	"github.com/spf13/cobra"
 )
 
 var updateCmd = &cobra.Command{
-	Use:   "update <id>",
+	Use:   "update [id]",
 	Short: "Update issue fields",
-	Args:  cobra.ExactArgs(1),
+	Long: `Updates fields of one issue, or of many at once.
+
+Bulk mode picks issues with --where, which takes nd list filter flags written
+as key=value pairs (default: every issue that is not closed), or with --stdin,
+which reads issue IDs. The same changes are applied to each issue under one
+vault lock, status changes pass the usual FSM, guard, WIP, and acceptance
+checks, and failures are reported per issue without stopping the rest.
+--set key=value is shorthand for the matching flag. --dry-run lists the
+issues and checks status changes without writing anything.
+
+  nd update --where 'status=open label=api' --set priority=1
+  nd update --where 'assignee=alice' --assignee bob --dry-run
+  nd ready --json | jq -r '.issues[].id' | nd update --stdin --add-label triage`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		id := args[0]
+		where, _ := cmd.Flags().GetString("where")
+		fromStdin, _ := cmd.Flags().GetBool("stdin")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		byQuery := cmd.Flags().Changed("where")
+
+		sets, _ := cmd.Flags().GetStringArray("set")
+		if err := applySets(cmd, sets); err != nil {
+			return usageError{err}
+		}
+
+		switch {
+		case byQuery && fromStdin, len(args) == 1 && (byQuery || fromStdin):
+			return usageError{fmt.Errorf("pass one of an issue ID, --where, or --stdin")}
+		case len(args) == 0 && !byQuery && !fromStdin:
+			return usageError{fmt.Errorf("requires an issue ID, --where, or --stdin")}
+		}
+		changes := updateChanges(cmd)
+		if len(changes) == 0 {
+			return fmt.Errorf("no fields specified to update")
+		}
+
+		// Read --body-file once; with --stdin, stdin holds the IDs.
+		var body string
+		if cmd.Flags().Changed("body-file") {
+			bf, _ := cmd.Flags().GetString("body-file")
+			if bf == "-" && fromStdin {
+				return usageError{fmt.Errorf("--body-file - cannot be combined with --stdin")}
+			}
+			var err error
+			if body, err = readBodyFile(bf); err != nil {
+				return err
+			}
+		}
+
 		s, err := store.Open(resolveVaultDir())
 		if err != nil {
 			return err
 		}
 		defer s.Close()
 
-		// Verify issue exists.
-		if _, err := s.ReadIssue(id); err != nil {
-			return fmt.Errorf("issue %s not found: %w", id, err)
+		if err := validateUpdateFlags(cmd, s); err != nil {
+			return err
 		}
 
-		changed := false
-
-		if cmd.Flags().Changed("status") {
-			v, _ := cmd.Flags().GetString("status")
-			st, err := model.ParseStatusWithCustom(v, s.CustomStatuses())
+		if len(args) == 1 && !dryRun {
+			id, err := s.ResolveID(args[0])
 			if err != nil {
 				return err
 			}
-			if err := s.UpdateStatus(id, st); err != nil {
+			// Verify issue exists.
+			if _, err := s.ReadIssue(id); err != nil {
 				return err
 			}
-			changed = true
-		}
-
-		if cmd.Flags().Changed("title") {
-			v, _ := cmd.Flags().GetString("title")
-			if err := s.UpdateField(id, "title", fmt.Sprintf("%q", v)); err != nil {
+			if err := updateIssue(cmd, s, id, body); err != nil {
 				return err
 			}
-			changed = true
+			if !quiet {
+				fmt.Printf("Updated %s\n", id)
+			}
+			return nil
 		}
 
-		if cmd.Flags().Changed("priority") {
-			v, _ := cmd.Flags().GetString("priority")
-			p, err := model.ParsePriority(v)
+		var inputs []string
+		switch {
+		case len(args) == 1:
+			inputs = args
+		case byQuery:
+			opts, err := whereFilterOptions(where)
+			if err != nil {
+				return usageError{err}
+			}
+			if opts.Parent != "" {
+				if opts.Parent, err = s.ResolveID(opts.Parent); err != nil {
+					return err
+				}
+			}
+			issues, err := s.ListIssues(opts)
 			if err != nil {
 				return err
 			}
-			if err := s.UpdateField(id, "priority", fmt.Sprintf("%d", p)); err != nil {
+			for _, issue := range issues {
+				inputs = append(inputs, issue.ID)
+			}
+		default:
+			if inputs, err = readIDs(os.Stdin); err != nil {
 				return err
 			}
-			changed = true
 		}
 
-		if cmd.Flags().Changed("assignee") {
-			v, _ := cmd.Flags().GetString("assignee")
-			if err := s.UpdateField(id, "assignee", v); err != nil {
-				return err
+		result := bulkUpdateJSON{
+			SchemaVersion: format.JSONSchemaVersion,
+			DryRun:        dryRun,
+			Changes:       changes,
+			Updated:       []string{},
+			Failed:        []bulkFailureJSON{},
+		}
+		if dryRun && !jsonOut && !quiet {
+			fmt.Printf("Changes: %s\n", strings.Join(changes, " "))
+		}
+		seen := make(map[string]bool)
+		for _, in := range inputs {
+			id, err := s.ResolveID(in)
+			if err == nil && seen[id] {
+				continue
+			}
+			if err == nil {
+				seen[id] = true
+				if dryRun {
+					err = checkUpdate(cmd, s, id)
+				} else {
+					err = updateIssue(cmd, s, id, body)
+				}
+			}
+			if err != nil {
+				if id == "" {
+					id = in
+				}
+				code, _ := errorCode(err)
+				result.Failed = append(result.Failed, bulkFailureJSON{ID: id, Code: code, Error: err.Error()})
+				if !jsonOut {
+					errorf("%s: %v", id, err)
+				}
+				continue
+			}
+			result.Updated = append(result.Updated, id)
+			if jsonOut || quiet {
+				continue
+			}
+			if dryRun {
+				issue, _ := s.ReadIssue(id)
+				fmt.Printf("Would update %s: %s\n", id, issue.Title)
+			} else {
+				fmt.Printf("Updated %s\n", id)
 			}
-			changed = true
 		}
 
-		if cmd.Flags().Changed("type") {
-			v, _ := cmd.Flags().GetString("type")
-			if _, err := model.ParseIssueType(v); err != nil {
+		if jsonOut {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(result); err != nil {
 				return err
 			}
-			if err := s.UpdateField(id, "type", v); err != nil {
-				return err
+		} else if len(inputs) == 0 && !quiet {
+			fmt.Println("No issues matched.")
+		}
+		if n := len(result.Failed); n > 0 {
+			err := fmt.Errorf("%d issue(s) failed to update", n)
+			if dryRun {
+				err = fmt.Errorf("%d issue(s) would fail to update", n)
 			}
-			changed = true
+			if jsonOut {
+				return reportedError{err}
+			}
+			return err
 		}
+		return nil
+	},
+}
 
-		if cmd.Flags().Changed("append-notes") {
-			v, _ := cmd.Flags().GetString("append-notes")
-			if err := s.AppendNotes(id, v); err != nil {
-				return err
-			}
-			changed = true
+// bulkUpdateJSON is the --json output of a bulk update.
+type bulkUpdateJSON struct {
+	SchemaVersion int               `json:"schema_version"`
+	DryRun        bool              `json:"dry_run"`
+	Changes       []string          `json:"changes"` // key=value for each flag applied
+	Updated       []string          `json:"updated"` // in a dry run, the IDs that would be updated
+	Failed        []bulkFailureJSON `json:"failed"`
+}
+
+type bulkFailureJSON struct {
+	ID    string `json:"id"`
+	Code  string `json:"code"` // as in the error object; see "Exit Codes"
+	Error string `json:"error"`
+}
+
+// updateFields lists the update flags that change an issue, in the order
+// they are applied.
+var updateFields = []string{
+	"status", "title", "priority", "assignee", "type", "append-notes",
+	"description", "body-file", "parent", "follows", "unfollow", "set-labels",
+	"add-label", "remove-label", "estimate", "due", "recur",
+}
+
+// setAliases maps --set keys to update flags; keys not listed here must
+// name an update flag themselves.
+var setAliases = map[string]string{"labels": "set-labels"}
+
+// settableKeys lists the keys --set accepts, sorted.
+func settableKeys() []string {
+	var keys []string
+	for _, f := range updateFields {
+		switch f {
+		case "append-notes", "body-file", "follows", "unfollow", "set-labels", "add-label", "remove-label":
+			continue
 		}
+		keys = append(keys, f)
+	}
+	for k := range setAliases {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
 
-		if cmd.Flags().Changed("description") {
-			v, _ := cmd.Flags().GetString("description")
-			if err := s.UpdateDescription(id, v); err != nil {
-				return err
-			}
-			changed = true
+// applySets turns each --set key=value into the matching update flag.
+func applySets(cmd *cobra.Command, sets []string) error {
+	allowed := settableKeys()
+	for _, kv := range sets {
+		key, value, ok := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --set %q: want key=value", kv)
+		}
+		if !containsString(allowed, key) {
+			return fmt.Errorf("invalid --set %q: unknown field %s (valid: %s)", kv, key, strings.Join(allowed, ", "))
+		}
+		name := key
+		if alias, ok := setAliases[key]; ok {
+			name = alias
 		}
+		if cmd.Flags().Changed(name) {
+			return fmt.Errorf("--set %s conflicts with --%s or an earlier --set", key, name)
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid --set %q: %w", kv, err)
+		}
+	}
+	return nil
+}
 
-		if cmd.Flags().Changed("body-file") {
-			bf, _ := cmd.Flags().GetString("body-file")
-			body, err := readBodyFile(bf)
-			if err != nil {
-				return err
-			}
-			if err := s.UpdateDescription(id, body); err != nil {
-				return err
-			}
-			changed = true
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
 		}
+	}
+	return false
+}
 
-		if cmd.Flags().Changed("parent") {
-			v, _ := cmd.Flags().GetString("parent")
-			if err := s.SetParent(id, v); err != nil {
-				return err
-			}
-			changed = true
+// updateChanges describes the update flags that were set, as key=value.
+func updateChanges(cmd *cobra.Command) []string {
+	var out []string
+	for _, name := range updateFields {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			out = append(out, name+"="+f.Value.String())
 		}
+	}
+	return out
+}
 
-		if cmd.Flags().Changed("follows") {
-			v, _ := cmd.Flags().GetString("follows")
-			if err := s.AddFollows(id, v); err != nil {
+// validateUpdateFlags checks the values of the update flags that need
+// parsing, so a bad value fails once, before anything is written and in
+// --dry-run too, rather than once per issue or after an earlier flag has
+// already changed it.
+func validateUpdateFlags(cmd *cobra.Command, s *store.Store) error {
+	if cmd.Flags().Changed("status") {
+		v, _ := cmd.Flags().GetString("status")
+		if _, err := model.ParseStatusWithCustom(v, s.CustomStatuses()); err != nil {
+			return usageError{err}
+		}
+	}
+	if cmd.Flags().Changed("priority") {
+		v, _ := cmd.Flags().GetString("priority")
+		if _, err := model.ParsePriority(v); err != nil {
+			return usageError{err}
+		}
+	}
+	if cmd.Flags().Changed("type") {
+		v, _ := cmd.Flags().GetString("type")
+		if _, err := model.ParseIssueType(v); err != nil {
+			return usageError{err}
+		}
+	}
+	if v, _ := cmd.Flags().GetString("estimate"); strings.TrimSpace(v) != "" {
+		if _, err := model.ParseEffort(v); err != nil {
+			return usageError{fmt.Errorf("invalid --estimate: %w", err)}
+		}
+	}
+	if v, _ := cmd.Flags().GetString("due"); strings.TrimSpace(v) != "" {
+		if _, err := dates.Resolve(v, time.Now()); err != nil {
+			return usageError{fmt.Errorf("invalid --due: %w", err)}
+		}
+	}
+	if v, _ := cmd.Flags().GetString("recur"); strings.TrimSpace(v) != "" {
+		if _, err := dates.ParseRecurrence(v); err != nil {
+			return usageError{fmt.Errorf("invalid --recur: %w", err)}
+		}
+	}
+	return nil
+}
+
+// whereFilterOptions parses a --where query: whitespace-separated nd list
+// filter flags written as key=value (or a bare key for no-parent). Closed
+// issues are skipped unless the query sets status.
+func whereFilterOptions(where string) (store.FilterOptions, error) {
+	fc := &cobra.Command{}
+	addFilterFlags(fc)
+	var flags []string
+	for _, tok := range strings.Fields(where) {
+		flags = append(flags, "--"+strings.TrimPrefix(tok, "--"))
+	}
+	if err := fc.ParseFlags(flags); err != nil {
+		return store.FilterOptions{}, fmt.Errorf("invalid --where: %w", err)
+	}
+	return buildFilterOptions(fc, "!closed")
+}
+
+// readIDs reads whitespace-separated issue IDs, skipping lines that start
+// with #.
+func readIDs(r io.Reader) ([]string, error) {
+	var ids []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, strings.Fields(line)...)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read IDs from stdin: %w", err)
+	}
+	return ids, nil
+}
+
+// checkUpdate makes the checks a dry run can make without writing: the
+// issue exists, a --status change passes the transition checks, and linked
+// issues resolve.
+func checkUpdate(cmd *cobra.Command, s *store.Store, id string) error {
+	if _, err := s.ReadIssue(id); err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("status") {
+		v, _ := cmd.Flags().GetString("status")
+		st, err := model.ParseStatusWithCustom(v, s.CustomStatuses())
+		if err != nil {
+			return usageError{err}
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := s.CheckStatus(id, st, store.TransitionOptions{Force: force}); err != nil {
+			return err
+		}
+	}
+	for _, name := range []string{"parent", "follows", "unfollow"} {
+		if v, _ := cmd.Flags().GetString(name); v != "" {
+			if _, err := s.ResolveID(v); err != nil {
 				return err
 			}
-			changed = true
 		}
-		if cmd.Flags().Changed("unfollow") {
-			v, _ := cmd.Flags().GetString("unfollow")
-			if err := s.RemoveFollows(id, v); err != nil {
+	}
+	return nil
+}
+
+// updateIssue applies the update flags set on cmd to one issue. body is the
+// --body-file content, read once by the caller.
+func updateIssue(cmd *cobra.Command, s *store.Store, id, body string) error {
+	var err error
+
+	if cmd.Flags().Changed("status") {
+		v, _ := cmd.Flags().GetString("status")
+		st, err := model.ParseStatusWithCustom(v, s.CustomStatuses())
+		if err != nil {
+			return usageError{err}
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if err := s.UpdateStatus(id, st, store.TransitionOptions{Force: force}); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("title") {
+		v, _ := cmd.Flags().GetString("title")
+		if err := s.UpdateField(id, "title", fmt.Sprintf("%q", v)); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("priority") {
+		v, _ := cmd.Flags().GetString("priority")
+		p, err := model.ParsePriority(v)
+		if err != nil {
+			return usageError{err}
+		}
+		if err := s.UpdateField(id, "priority", fmt.Sprintf("%d", p)); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("assignee") {
+		v, _ := cmd.Flags().GetString("assignee")
+		if err := s.UpdateField(id, "assignee", v); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("type") {
+		v, _ := cmd.Flags().GetString("type")
+		if _, err := model.ParseIssueType(v); err != nil {
+			return usageError{err}
+		}
+		if err := s.UpdateField(id, "type", v); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("append-notes") {
+		v, _ := cmd.Flags().GetString("append-notes")
+		if err := s.AppendNotes(id, v); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("description") {
+		v, _ := cmd.Flags().GetString("description")
+		if err := s.UpdateDescription(id, v); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("body-file") {
+		if err := s.UpdateDescription(id, body); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("parent") {
+		v, _ := cmd.Flags().GetString("parent")
+		if v != "" {
+			if v, err = s.ResolveID(v); err != nil {
 				return err
 			}
-			changed = true
 		}
+		if err := s.SetParent(id, v); err != nil {
+			return err
+		}
+	}
 
-		if cmd.Flags().Changed("set-labels") {
-			v, _ := cmd.Flags().GetString("set-labels")
-			if v == "" {
-				if err := s.Vault().PropertyRemove(id, "labels"); err != nil {
-					return err
-				}
-			} else {
-				var labels []string
-				for _, l := range strings.Split(v, ",") {
-					l = strings.TrimSpace(l)
-					if l != "" {
-						labels = append(labels, l)
-					}
-				}
-				value := fmt.Sprintf("[%s]", strings.Join(labels, ", "))
-				if err := s.UpdateField(id, "labels", value); err != nil {
-					return err
-				}
-			}
-			changed = true
+	if cmd.Flags().Changed("follows") {
+		v, _ := cmd.Flags().GetString("follows")
+		if v, err = s.ResolveID(v); err != nil {
+			return err
+		}
+		if err := s.AddFollows(id, v); err != nil {
+			return err
+		}
+	}
+	if cmd.Flags().Changed("unfollow") {
+		v, _ := cmd.Flags().GetString("unfollow")
+		if v, err = s.ResolveID(v); err != nil {
+			return err
+		}
+		if err := s.RemoveFollows(id, v); err != nil {
+			return err
 		}
+	}
 
-		if cmd.Flags().Changed("add-label") || cmd.Flags().Changed("remove-label") {
-			issue, err := s.ReadIssue(id)
-			if err != nil {
+	if cmd.Flags().Changed("set-labels") {
+		v, _ := cmd.Flags().GetString("set-labels")
+		if v == "" {
+			if err := s.Vault().PropertyRemove(id, "labels"); err != nil {
 				return err
 			}
-			labels := issue.Labels
-
-			if cmd.Flags().Changed("add-label") {
-				toAdd, _ := cmd.Flags().GetStringSlice("add-label")
-				for _, add := range toAdd {
-					found := false
-					for _, l := range labels {
-						if strings.EqualFold(l, add) {
-							found = true
-							break
-						}
-					}
-					if !found {
-						labels = append(labels, add)
-					}
+		} else {
+			var labels []string
+			for _, l := range strings.Split(v, ",") {
+				l = strings.TrimSpace(l)
+				if l != "" {
+					labels = append(labels, l)
 				}
 			}
+			value := fmt.Sprintf("[%s]", strings.Join(labels, ", "))
+			if err := s.UpdateField(id, "labels", value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if cmd.Flags().Changed("add-label") || cmd.Flags().Changed("remove-label") {
+		issue, err := s.ReadIssue(id)
+		if err != nil {
+			return err
+		}
+		labels := issue.Labels
 
-			if cmd.Flags().Changed("remove-label") {
-				toRemove, _ := cmd.Flags().GetStringSlice("remove-label")
-				var filtered []string
+		if cmd.Flags().Changed("add-label") {
+			toAdd, _ := cmd.Flags().GetStringSlice("add-label")
+			for _, add := range toAdd {
+				found := false
 				for _, l := range labels {
-					keep := true
-					for _, rm := range toRemove {
-						if strings.EqualFold(l, rm) {
-							keep = false
-							break
-						}
-					}
-					if keep {
-						filtered = append(filtered, l)
+					if strings.EqualFold(l, add) {
+						found = true
+						break
 					}
 				}
-				labels = filtered
+				if !found {
+					labels = append(labels, add)
+				}
 			}
+		}
 
-			if len(labels) == 0 {
-				if err := s.Vault().PropertyRemove(id, "labels"); err != nil {
-					return err
+		if cmd.Flags().Changed("remove-label") {
+			toRemove, _ := cmd.Flags().GetStringSlice("remove-label")
+			var filtered []string
+			for _, l := range labels {
+				keep := true
+				for _, rm := range toRemove {
+					if strings.EqualFold(l, rm) {
+						keep = false
+						break
+					}
 				}
-			} else {
-				value := fmt.Sprintf("[%s]", strings.Join(labels, ", "))
-				if err := s.UpdateField(id, "labels", value); err != nil {
-					return err
+				if keep {
+					filtered = append(filtered, l)
 				}
 			}
-			changed = true
+			labels = filtered
 		}
 
-		if !changed {
-			return fmt.Errorf("no fields specified to update")
+		if len(labels) == 0 {
+			if err := s.Vault().PropertyRemove(id, "labels"); err != nil {
+				return err
+			}
+		} else {
+			value := fmt.Sprintf("[%s]", strings.Join(labels, ", "))
+			if err := s.UpdateField(id, "labels", value); err != nil {
+				return err
+			}
 		}
+	}
 
-		if !quiet {
-			fmt.Printf("Updated %s\n", id)
+	if cmd.Flags().Changed("estimate") {
+		v, _ := cmd.Flags().GetString("estimate")
+		if err := s.SetEstimate(id, v); err != nil {
+			return err
 		}
-		return nil
-	},
+	}
+
+	if cmd.Flags().Changed("due") {
+		v, _ := cmd.Flags().GetString("due")
+		if err := s.SetDue(id, v); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("recur") {
+		v, _ := cmd.Flags().GetString("recur")
+		if err := s.SetRecurrence(id, v); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func init() {
 	updateCmd.Flags().String("status", "", "new status")
+	updateCmd.Flags().Bool("force", false, "override acceptance-criteria gates and WIP limits on --status (logged in history)")
 	updateCmd.Flags().String("title", "", "new title")
 	updateCmd.Flags().String("priority", "", "new priority (0-4 or P0-P4)")
 	updateCmd.Flags().String("assignee", "", "new assignee")
@@ -232,5 +603,12 @@ func init() {
 	updateCmd.Flags().String("set-labels", "", "replace all labels (comma-separated, empty to clear)")
 	updateCmd.Flags().StringSlice("add-label", nil, "add labels")
 	updateCmd.Flags().StringSlice("remove-label", nil, "remove labels")
+	updateCmd.Flags().String("estimate", "", "effort estimate (e.g. 4h, 1.5d; empty to clear)")
+	updateCmd.Flags().String("due", "", "due date (YYYY-MM-DD, tomorrow, +3d, ...; empty to clear)")
+	updateCmd.Flags().String("recur", "", "recurrence rule, e.g. 2w, monthly, \"0 9 * * 1\" (empty to clear)")
+	updateCmd.Flags().StringArray("set", nil, "set a field, as key=value (repeatable): "+strings.Join(settableKeys(), ", "))
+	updateCmd.Flags().String("where", "", "update every issue matching filters, e.g. 'status=open label=api' (nd list flags as key=value)")
+	updateCmd.Flags().Bool("stdin", false, "update the issue IDs read from stdin (whitespace-separated; # starts a comment line)")
+	updateCmd.Flags().Bool("dry-run", false, "show which issues would be updated and check status changes without writing")
 	rootCmd.AddCommand(updateCmd)
 }
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+func TestApplySets(t *testing.T) {
+	c := &cobra.Command{}
+	for _, name := range updateFields {
+		c.Flags().String(name, "", "")
+	}
+	if err := applySets(c, []string{"priority=1", "labels=a,b", "title=x=y"}); err != nil {
+		t.Fatalf("applySets: %v", err)
+	}
+	want := []string{"title=x=y", "priority=1", "set-labels=a,b"}
+	if got := updateChanges(c); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("changes = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"priority", "=1", "follows=X", "nope=1", "priority=2"} {
+		if err := applySets(c, []string{bad}); err == nil {
+			t.Errorf("applySets(%q) should fail", bad)
+		}
+	}
+}
+
+func TestValidateUpdateFlags(t *testing.T) {
+	s, err := store.Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	for _, tt := range []struct {
+		set  string
+		fail bool
+	}{
+		{"due=2027-01-01", false}, {"due=", false}, {"due=garbage", true},
+		{"estimate=4h", false}, {"estimate=soon", true},
+		{"recur=2w", false}, {"recur=fortnightly", true},
+		{"status=bogus", true}, {"priority=9", true},
+	} {
+		c := &cobra.Command{}
+		for _, name := range updateFields {
+			c.Flags().String(name, "", "")
+		}
+		if err := applySets(c, []string{tt.set}); err != nil {
+			t.Fatalf("applySets(%q): %v", tt.set, err)
+		}
+		err := validateUpdateFlags(c, s)
+		if tt.fail != (err != nil) {
+			t.Errorf("%s: err = %v, want failure %v", tt.set, err, tt.fail)
+		}
+		if code, _ := errorCode(err); err != nil && code != "validation" {
+			t.Errorf("%s: code = %s, want validation", tt.set, code)
+		}
+	}
+}
+
+func TestWhereFilterOptions(t *testing.T) {
+	opts, err := whereFilterOptions("label=api --assignee=bob no-parent")
+	if err != nil {
+		t.Fatalf("whereFilterOptions: %v", err)
+	}
+	if opts.Label != "api" || opts.Assignee != "bob" || !opts.NoParent || opts.Status != "!closed" {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	opts, err = whereFilterOptions("status=all")
+	if err != nil || opts.Status != "all" {
+		t.Errorf("status=all: opts.Status = %q, err = %v", opts.Status, err)
+	}
+
+	for _, bad := range []string{"bogus=1", "created-after=notadate"} {
+		if _, err := whereFilterOptions(bad); err == nil {
+			t.Errorf("whereFilterOptions(%q) should fail", bad)
+		}
+	}
+}
+
+func TestReadIDs(t *testing.T) {
+	in := "T-a1 T-b2\n\n# skipped T-c3\n  T-d4\n"
+	got, err := readIDs(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("readIDs: %v", err)
+	}
+	if strings.Join(got, ",") != "T-a1,T-b2,T-d4" {
+		t.Errorf("ids = %v", got)
+	}
+}
@@ -0,0 +1,407 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// anyStatus is the pseudo-node for "every other status" in diagrams.
+// Status names are lowercase, so the uppercase id cannot collide.
+const anyStatus = "ANY"
+
+var workflowCmd = &cobra.Command{
+	Use:   "workflow",
+	Short: "Inspect the status workflow",
+}
+
+var workflowShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Render the effective status FSM",
+	Long:  "Renders a workflow (default, an issue type, or label:<name>) as an ASCII summary, Graphviz DOT, or a Mermaid state diagram.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		name := store.DefaultWorkflow
+		if len(args) == 1 {
+			name = strings.ToLower(args[0])
+		}
+		wf, err := s.Workflow(name)
+		if err != nil {
+			return err
+		}
+		view := workflowView{
+			Name:        wf.Name,
+			FSM:         s.Config().StatusFSM,
+			Sequence:    wf.Sequence,
+			Statuses:    s.StatusDefs(),
+			Transitions: s.WorkflowTransitions(wf),
+		}
+
+		if jsonOut {
+			if view.Sequence == nil {
+				view.Sequence = []model.Status{}
+			}
+			if view.Transitions == nil {
+				view.Transitions = []store.Transition{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(view)
+		}
+		switch format {
+		case "ascii":
+			renderWorkflowASCII(os.Stdout, view)
+		case "dot":
+			renderWorkflowDOT(os.Stdout, view)
+		case "mermaid":
+			renderWorkflowMermaid(os.Stdout, view)
+		default:
+			return fmt.Errorf("unknown format %q: must be ascii, dot, or mermaid", format)
+		}
+		return nil
+	},
+}
+
+var workflowNextCmd = &cobra.Command{
+	Use:   "next <id>",
+	Short: "List the statuses an issue may legally move to now",
+	Long: `Lists the statuses the issue's workflow lets it move to, and which of those
+a guard, WIP limit, or acceptance gate refuses right now, with the reason.
+These are the same checks nd update --status applies.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		s, err := store.Open(resolveVaultDir())
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+
+		if id, err = s.ResolveID(id); err != nil {
+			return err
+		}
+
+		issue, err := s.ReadIssue(id)
+		if err != nil {
+			return err
+		}
+		wf := s.WorkflowFor(issue)
+		next, refused := s.AllowedTransitions(issue)
+
+		if jsonOut {
+			if next == nil {
+				next = []store.Transition{}
+			}
+			if refused == nil {
+				refused = []store.Transition{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.SetEscapeHTML(false) // refusals contain "->"
+			return enc.Encode(map[string]any{
+				"id":       issue.ID,
+				"status":   issue.Status,
+				"workflow": wf.Name,
+				"next":     next,
+				"refused":  refused,
+			})
+		}
+
+		fmt.Printf("%s is %s (%s workflow); it may move to:\n", issue.ID, issue.Status, wf.Name)
+		if len(next) == 0 {
+			fmt.Println("  (nothing right now)")
+		}
+		for _, t := range next {
+			if label := transitionLabel(t.Kind); label != "" {
+				fmt.Printf("  %-14s %s\n", t.To, label)
+			} else {
+				fmt.Printf("  %s\n", t.To)
+			}
+		}
+		if len(refused) > 0 {
+			fmt.Println("Refused right now:")
+			for _, t := range refused {
+				fmt.Printf("  %-14s %s\n", t.To, strings.ReplaceAll(t.Refused, "\n", "\n"+strings.Repeat(" ", 17)))
+			}
+		}
+		return nil
+	},
+}
+
+// workflowView is the renderable form of one workflow.
+type workflowView struct {
+	Name        string             `json:"name"`
+	FSM         bool               `json:"fsm"`
+	Sequence    []model.Status     `json:"sequence"`
+	Statuses    []store.StatusDef  `json:"statuses"`
+	Transitions []store.Transition `json:"transitions"`
+}
+
+func transitionLabel(kind string) string {
+	switch kind {
+	case store.TransitionForward:
+		return "next"
+	case store.TransitionBack:
+		return "back"
+	case store.TransitionExit:
+		return "exit rule"
+	case store.TransitionReopen:
+		return "reopen"
+	default:
+		return ""
+	}
+}
+
+// orderedStatuses lists sequence statuses first, then the rest in
+// declaration order.
+func (v workflowView) orderedStatuses() []model.Status {
+	seen := make(map[model.Status]bool)
+	var out []model.Status
+	for _, st := range v.Sequence {
+		out = append(out, st)
+		seen[st] = true
+	}
+	for _, d := range v.Statuses {
+		if st := model.Status(d.Name); !seen[st] {
+			out = append(out, st)
+		}
+	}
+	return out
+}
+
+// edges collapses the transition list for drawing. An off-sequence status
+// that may move to every other status gets a single edge to ANY, and a
+// status every remaining (non-closed) status may enter unrestricted gets a
+// single edge from ANY. Sequence, exit-rule, and reopen edges are always drawn.
+func (v workflowView) edges() []store.Transition {
+	statuses := v.orderedStatuses()
+	targets := make(map[model.Status]map[model.Status]store.Transition)
+	for _, t := range v.Transitions {
+		if targets[t.From] == nil {
+			targets[t.From] = make(map[model.Status]store.Transition)
+		}
+		targets[t.From][t.To] = t
+	}
+
+	anyOut := make(map[model.Status]bool)
+	var restricted []model.Status
+	for _, from := range statuses {
+		if from == model.StatusClosed {
+			continue
+		}
+		if v.isEscapeHatch(from, len(targets[from]), len(statuses)) {
+			anyOut[from] = true
+		} else {
+			restricted = append(restricted, from)
+		}
+	}
+	anyIn := make(map[model.Status]bool)
+	if len(restricted) > 1 {
+		for _, to := range statuses {
+			all := true
+			for _, from := range restricted {
+				if t, ok := targets[from][to]; from != to && (!ok || t.Kind != store.TransitionEscape) {
+					all = false
+					break
+				}
+			}
+			anyIn[to] = all
+		}
+	}
+
+	var out []store.Transition
+	for _, to := range statuses {
+		if anyIn[to] {
+			out = append(out, store.Transition{From: anyStatus, To: to, Kind: store.TransitionEscape})
+		}
+	}
+	for _, from := range statuses {
+		if anyOut[from] {
+			out = append(out, store.Transition{From: from, To: anyStatus, Kind: store.TransitionEscape})
+			continue
+		}
+		for _, to := range statuses {
+			t, ok := targets[from][to]
+			if !ok || (anyIn[to] && t.Kind == store.TransitionEscape) {
+				continue
+			}
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// isEscapeHatch reports whether a status is off-sequence and may move to
+// every other status.
+func (v workflowView) isEscapeHatch(st model.Status, targets, statuses int) bool {
+	for _, seq := range v.Sequence {
+		if seq == st {
+			return false
+		}
+	}
+	return st != model.StatusClosed && targets == statuses-1
+}
+
+func (v workflowView) hasAny(edges []store.Transition) bool {
+	for _, e := range edges {
+		if e.From == anyStatus || e.To == anyStatus {
+			return true
+		}
+	}
+	return false
+}
+
+func renderWorkflowASCII(w io.Writer, v workflowView) {
+	mode := "FSM enforced"
+	if !v.FSM {
+		mode = "FSM disabled: every transition is allowed"
+	}
+	fmt.Fprintf(w, "Workflow: %s (%s)\n", v.Name, mode)
+	if len(v.Sequence) > 0 {
+		parts := make([]string, len(v.Sequence))
+		for i, st := range v.Sequence {
+			parts[i] = string(st)
+		}
+		fmt.Fprintf(w, "Sequence: %s\n", strings.Join(parts, " -> "))
+	}
+	fmt.Fprintln(w)
+
+	byFrom := make(map[model.Status][]store.Transition)
+	for _, t := range v.Transitions {
+		byFrom[t.From] = append(byFrom[t.From], t)
+	}
+	statuses := v.orderedStatuses()
+	for _, from := range statuses {
+		ts := byFrom[from]
+		var line string
+		switch {
+		case !v.FSM && from != model.StatusClosed:
+			line = "any"
+		case v.isEscapeHatch(from, len(ts), len(statuses)):
+			line = "any  (off-sequence escape hatch)"
+		default:
+			groups := make(map[string][]string)
+			for _, t := range ts {
+				label := transitionLabel(t.Kind)
+				if label == "" {
+					label = "other"
+				}
+				groups[label] = append(groups[label], string(t.To))
+			}
+			var parts []string
+			for _, label := range []string{"next", "back", "exit rule", "reopen", "other"} {
+				if names, ok := groups[label]; ok {
+					parts = append(parts, label+": "+strings.Join(names, ", "))
+				}
+			}
+			line = strings.Join(parts, "  ")
+			if line == "" {
+				line = "(none)"
+			}
+		}
+		fmt.Fprintf(w, "  %-14s %s\n", from, line)
+	}
+
+	var described []store.StatusDef
+	for _, d := range v.Statuses {
+		if d.Description != "" {
+			described = append(described, d)
+		}
+	}
+	if len(described) > 0 {
+		fmt.Fprintln(w)
+		for _, d := range described {
+			fmt.Fprintf(w, "  %-14s %s\n", d.Name, d.Description)
+		}
+	}
+}
+
+func renderWorkflowDOT(w io.Writer, v workflowView) {
+	edges := v.edges()
+	fmt.Fprintf(w, "digraph %q {\n", "workflow_"+v.Name)
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, style=rounded];")
+	meta := make(map[string]store.StatusDef)
+	for _, d := range v.Statuses {
+		meta[d.Name] = d
+	}
+	for _, st := range v.orderedStatuses() {
+		attrs := []string{fmt.Sprintf("label=%q", string(st))}
+		if d := meta[string(st)]; d.Description != "" {
+			attrs = append(attrs, fmt.Sprintf("tooltip=%q", d.Description))
+		}
+		if d := meta[string(st)]; d.Color != "" {
+			attrs = append(attrs, fmt.Sprintf("color=%q", d.Color))
+		}
+		fmt.Fprintf(w, "  %q [%s];\n", string(st), strings.Join(attrs, ", "))
+	}
+	if v.hasAny(edges) {
+		fmt.Fprintf(w, "  %q [label=\"any status\", shape=plaintext];\n", anyStatus)
+	}
+	for _, e := range edges {
+		var attrs []string
+		switch e.Kind {
+		case store.TransitionForward:
+			attrs = append(attrs, "style=bold")
+		case store.TransitionBack:
+			attrs = append(attrs, "style=dashed")
+		case store.TransitionExit:
+			attrs = append(attrs, `label="exit rule"`)
+		case store.TransitionReopen:
+			attrs = append(attrs, "style=dotted", `label="reopen"`)
+		}
+		suffix := ""
+		if len(attrs) > 0 {
+			suffix = " [" + strings.Join(attrs, ", ") + "]"
+		}
+		fmt.Fprintf(w, "  %q -> %q%s;\n", string(e.From), string(e.To), suffix)
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func renderWorkflowMermaid(w io.Writer, v workflowView) {
+	edges := v.edges()
+	fmt.Fprintln(w, "stateDiagram-v2")
+	fmt.Fprintln(w, "  direction LR")
+	if v.hasAny(edges) {
+		fmt.Fprintf(w, "  state \"any status\" as %s\n", anyStatus)
+	}
+	for _, d := range v.Statuses {
+		if d.Description != "" {
+			fmt.Fprintf(w, "  %s : %s\n", d.Name, d.Description)
+		}
+	}
+	for _, e := range edges {
+		if label := transitionLabel(e.Kind); label != "" {
+			fmt.Fprintf(w, "  %s --> %s : %s\n", e.From, e.To, label)
+		} else {
+			fmt.Fprintf(w, "  %s --> %s\n", e.From, e.To)
+		}
+	}
+	for _, d := range v.Statuses {
+		if d.Color != "" {
+			fmt.Fprintf(w, "  classDef c_%s color:%s\n", d.Name, d.Color)
+			fmt.Fprintf(w, "  class %s c_%s\n", d.Name, d.Name)
+		}
+	}
+}
+
+func init() {
+	workflowShowCmd.Flags().String("format", "ascii", "output format: ascii, dot, mermaid")
+	workflowCmd.AddCommand(workflowShowCmd)
+	workflowCmd.AddCommand(workflowNextCmd)
+	rootCmd.AddCommand(workflowCmd)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/store"
+)
+
+func testWorkflowView() workflowView {
+	tr := func(from, to model.Status, kind string) store.Transition {
+		return store.Transition{From: from, To: to, Kind: kind}
+	}
+	return workflowView{
+		Name:     "default",
+		FSM:      true,
+		Sequence: []model.Status{"open", "in_progress", "closed"},
+		Statuses: []store.StatusDef{
+			{Name: "open"}, {Name: "in_progress"}, {Name: "closed"},
+			{Name: "rejected", Description: "Won't do", Color: "#f26d78"},
+		},
+		Transitions: []store.Transition{
+			tr("open", "in_progress", store.TransitionForward),
+			tr("open", "rejected", store.TransitionEscape),
+			tr("in_progress", "open", store.TransitionBack),
+			tr("in_progress", "closed", store.TransitionForward),
+			tr("in_progress", "rejected", store.TransitionEscape),
+			tr("closed", "open", store.TransitionReopen),
+			tr("rejected", "open", store.TransitionEscape),
+			tr("rejected", "in_progress", store.TransitionEscape),
+			tr("rejected", "closed", store.TransitionEscape),
+		},
+	}
+}
+
+func TestWorkflowEdgesCollapseEscapeHatches(t *testing.T) {
+	var got []string
+	for _, e := range testWorkflowView().edges() {
+		got = append(got, string(e.From)+">"+string(e.To))
+	}
+	want := "ANY>rejected open>in_progress in_progress>open in_progress>closed closed>open rejected>ANY"
+	if strings.Join(got, " ") != want {
+		t.Errorf("edges = %s\nwant    %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestRenderWorkflowFormats(t *testing.T) {
+	v := testWorkflowView()
+
+	var buf bytes.Buffer
+	renderWorkflowASCII(&buf, v)
+	for _, want := range []string{
+		"Sequence: open -> in_progress -> closed",
+		"in_progress    next: closed  back: open  other: rejected",
+		"rejected       any  (off-sequence escape hatch)",
+		"Won't do",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("ascii missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	renderWorkflowDOT(&buf, v)
+	for _, want := range []string{
+		`"in_progress" -> "closed" [style=bold];`,
+		`"closed" -> "open" [style=dotted, label="reopen"];`,
+		`"rejected" [label="rejected", tooltip="Won't do", color="#f26d78"];`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("dot missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	renderWorkflowMermaid(&buf, v)
+	for _, want := range []string{"stateDiagram-v2", "in_progress --> open : back", "rejected --> ANY", "class rejected c_rejected"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("mermaid missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+// Package dates parses the date arguments nd accepts on the command line
+// and in frontmatter: absolute dates, RFC3339 timestamps, and relative
+// offsets such as +2w.
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layout is the date-only format nd writes to frontmatter.
+const Layout = "2006-01-02"
+
+var relativeRe = regexp.MustCompile(`^([+-])(\d+)([hdwmy])$`)
+
+// Parse interprets s relative to now. Accepted forms:
+//
+//	2026-03-01            calendar date (midnight UTC)
+//	2026-03-01T09:00:00Z  RFC3339 timestamp
+//	today, tomorrow       midnight UTC of that day
+//	+3d, +2w, -1w         offset in hours (h), days (d), weeks (w), months (m), or years (y)
+func Parse(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch s {
+	case "":
+		return time.Time{}, fmt.Errorf("empty date")
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if m := relativeRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		switch m[3] {
+		case "h":
+			return now.Add(time.Duration(n) * time.Hour), nil
+		case "d":
+			return today.AddDate(0, 0, n), nil
+		case "w":
+			return today.AddDate(0, 0, 7*n), nil
+		case "m":
+			return today.AddDate(0, n, 0), nil
+		default:
+			return today.AddDate(n, 0, 0), nil
+		}
+	}
+
+	if t, err := time.Parse(Layout, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(s)); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD, RFC3339, today, tomorrow, or an offset like +3d, +2w, +1m", s)
+}
+
+// Relative reports whether s depends on the current time: today, tomorrow,
+// or an offset such as +3d.
+func Relative(s string) bool {
+	s = strings.TrimSpace(strings.ToLower(s))
+	return s == "today" || s == "tomorrow" || relativeRe.MatchString(s)
+}
+
+// Resolve parses s and returns it in the form nd stores: a plain date for
+// day-granular input, RFC3339 when the input carried a time of day.
+func Resolve(s string, now time.Time) (string, error) {
+	t, err := Parse(s, now)
+	if err != nil {
+		return "", err
+	}
+	return Format(t), nil
+}
+
+// Format returns t in the form nd stores: a plain date at midnight UTC,
+// RFC3339 otherwise.
+func Format(t time.Time) string {
+	t = t.UTC()
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format(Layout)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// Reached reports whether a stored date (YYYY-MM-DD or RFC3339) is at or
+// before now. Unparseable values are never reached.
+func Reached(stored string, now time.Time) bool {
+	t, err := Parse(stored, now)
+	if err != nil || relativeRe.MatchString(strings.TrimSpace(stored)) {
+		return false
+	}
+	return !t.After(now)
+}
@@ -0,0 +1,81 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2026-05-01", "2026-05-01T00:00:00Z"},
+		{"2026-05-01T09:00:00Z", "2026-05-01T09:00:00Z"},
+		{"today", "2026-03-04T00:00:00Z"},
+		{"tomorrow", "2026-03-05T00:00:00Z"},
+		{"+3d", "2026-03-07T00:00:00Z"},
+		{"+2w", "2026-03-18T00:00:00Z"},
+		{"-1w", "2026-02-25T00:00:00Z"},
+		{"+1m", "2026-04-04T00:00:00Z"},
+		{"+1y", "2027-03-04T00:00:00Z"},
+		{"+6h", "2026-03-04T21:30:00Z"},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.input, now)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.input, err)
+			continue
+		}
+		if got.Format(time.RFC3339) != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.input, got.Format(time.RFC3339), tt.want)
+		}
+	}
+	for _, bad := range []string{"", "next week", "+2", "2w", "2026-13-01"} {
+		if _, err := Parse(bad, now); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if got, _ := Resolve("+2w", now); got != "2026-03-18" {
+		t.Errorf("Resolve(+2w) = %q", got)
+	}
+	if got, _ := Resolve("+6h", now); got != "2026-03-04T21:30:00Z" {
+		t.Errorf("Resolve(+6h) = %q", got)
+	}
+}
+
+func TestRelative(t *testing.T) {
+	for in, want := range map[string]bool{
+		"today": true, " Tomorrow": true, "+2w": true, "-1d": true,
+		"2026-03-01": false, "2026-03-01T09:00:00Z": false, "soon": false,
+	} {
+		if got := Relative(in); got != want {
+			t.Errorf("Relative(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestReached(t *testing.T) {
+	tests := []struct {
+		stored string
+		want   bool
+	}{
+		{"2026-03-04", true},
+		{"2026-03-01", true},
+		{"2026-03-05", false},
+		{"2026-03-04T15:00:00Z", true},
+		{"2026-03-04T16:00:00Z", false},
+		{"+1d", false},
+		{"garbage", false},
+	}
+	for _, tt := range tests {
+		if got := Reached(tt.stored, now); got != tt.want {
+			t.Errorf("Reached(%q) = %v, want %v", tt.stored, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var intervalRe = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+var namedIntervals = map[string]string{
+	"daily":     "1d",
+	"weekly":    "1w",
+	"biweekly":  "2w",
+	"monthly":   "1m",
+	"quarterly": "3m",
+	"yearly":    "1y",
+}
+
+// Recurrence is a parsed recurrence rule: either a fixed interval counted
+// from a reference time, or a five-field cron spec.
+type Recurrence struct {
+	spec                string
+	years, months, days int
+	cron                *cronSpec
+}
+
+// ParseRecurrence accepts:
+//
+//	2w, every 2w          interval in days (d), weeks (w), months (m), or years (y)
+//	daily, weekly, ...    daily, weekly, biweekly, monthly, quarterly, yearly
+//	0 9 * * 1             cron: minute hour day-of-month month day-of-week (UTC)
+//
+// Cron fields take *, a number, a range (1-5), a list (1,15), or a step (*/2).
+func ParseRecurrence(spec string) (Recurrence, error) {
+	s := strings.TrimSpace(strings.ToLower(spec))
+	s = strings.TrimSpace(strings.TrimPrefix(s, "every "))
+	if named, ok := namedIntervals[s]; ok {
+		s = named
+	}
+	if m := intervalRe.FindStringSubmatch(s); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if n == 0 {
+			return Recurrence{}, fmt.Errorf("invalid recurrence %q: interval must be positive", spec)
+		}
+		r := Recurrence{spec: s}
+		switch m[2] {
+		case "d":
+			r.days = n
+		case "w":
+			r.days = 7 * n
+		case "m":
+			r.months = n
+		default:
+			r.years = n
+		}
+		return r, nil
+	}
+	if len(strings.Fields(s)) == 5 {
+		c, err := parseCron(s)
+		if err != nil {
+			return Recurrence{}, fmt.Errorf("invalid recurrence %q: %w", spec, err)
+		}
+		if c.next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+			return Recurrence{}, fmt.Errorf("invalid recurrence %q: the cron spec never matches", spec)
+		}
+		return Recurrence{spec: strings.Join(strings.Fields(s), " "), cron: c}, nil
+	}
+	return Recurrence{}, fmt.Errorf("invalid recurrence %q: use an interval like 2w, a name like weekly, or a cron spec like \"0 9 * * 1\"", spec)
+}
+
+// String returns the normalized rule, suitable for storing in frontmatter.
+func (r Recurrence) String() string { return r.spec }
+
+// Next returns the first occurrence strictly after t. Interval rules are
+// day-granular and land on midnight UTC; cron rules land on the matching
+// minute. Returns the zero time when a cron spec never matches.
+func (r Recurrence) Next(t time.Time) time.Time {
+	t = t.UTC()
+	if r.cron != nil {
+		return r.cron.next(t)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(r.years, r.months, r.days)
+}
+
+type cronSpec struct {
+	minute, hour, dom, month, dow map[int]bool
+	domAny, dowAny                bool
+}
+
+func parseCron(s string) (*cronSpec, error) {
+	f := strings.Fields(s)
+	var c cronSpec
+	var err error
+	if c.minute, err = parseCronField(f[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if c.hour, err = parseCronField(f[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if c.dom, err = parseCronField(f[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if c.month, err = parseCronField(f[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if c.dow, err = parseCronField(f[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	if c.dow[7] {
+		c.dow[0] = true // both 0 and 7 mean Sunday
+	}
+	c.domAny, c.dowAny = f[2] == "*", f[4] == "*"
+	return &c, nil
+}
+
+func parseCronField(field string, lo, hi int) (map[int]bool, error) {
+	set := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			var err error
+			if from, err = strconv.Atoi(a); err != nil {
+				return nil, fmt.Errorf("invalid value %q", part)
+			}
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(b); err != nil {
+					return nil, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return nil, fmt.Errorf("%q out of range %d-%d", part, lo, hi)
+		}
+		for v := from; v <= to; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// matchesDay applies cron's day rule: when both day-of-month and
+// day-of-week are restricted, a day matching either one qualifies.
+func (c *cronSpec) matchesDay(d time.Time) bool {
+	if !c.month[int(d.Month())] {
+		return false
+	}
+	domOK, dowOK := c.dom[d.Day()], c.dow[int(d.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowOK
+	case c.dowAny:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
+
+func (c *cronSpec) next(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// Five years covers every satisfiable spec, including Feb 29.
+	for i := 0; i < 5*366; i++ {
+		d := day.AddDate(0, 0, i)
+		if !c.matchesDay(d) {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if !c.hour[h] {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if c.minute[m] {
+					if at := d.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute); at.After(t) {
+						return at
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}
+}
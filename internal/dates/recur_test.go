@@ -0,0 +1,42 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecurrenceNext(t *testing.T) {
+	tests := []struct {
+		spec string
+		norm string
+		want string
+	}{
+		{"2w", "2w", "2026-03-18T00:00:00Z"},
+		{"every 3d", "3d", "2026-03-07T00:00:00Z"},
+		{"monthly", "1m", "2026-04-04T00:00:00Z"},
+		{"quarterly", "3m", "2026-06-04T00:00:00Z"},
+		{"0 9 * * 1", "0 9 * * 1", "2026-03-09T09:00:00Z"},      // next Monday 09:00
+		{"30 15 * * *", "30 15 * * *", "2026-03-05T15:30:00Z"},  // strictly after now
+		{"0 0 1 */3 *", "0 0 1 */3 *", "2026-04-01T00:00:00Z"},  // quarterly on the 1st
+		{"0 8 15 * 5", "0 8 15 * 5", "2026-03-06T08:00:00Z"},    // Friday or the 15th
+		{"0  12 29 2 *", "0 12 29 2 *", "2028-02-29T12:00:00Z"}, // next leap day
+	}
+	for _, tt := range tests {
+		r, err := ParseRecurrence(tt.spec)
+		if err != nil {
+			t.Errorf("ParseRecurrence(%q): %v", tt.spec, err)
+			continue
+		}
+		if r.String() != tt.norm {
+			t.Errorf("ParseRecurrence(%q).String() = %q, want %q", tt.spec, r.String(), tt.norm)
+		}
+		if got := r.Next(now).Format(time.RFC3339); got != tt.want {
+			t.Errorf("%q.Next = %s, want %s", tt.spec, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "0w", "fortnightly", "60 * * * *", "* * * *", "0 0 31 2 *", "*/0 * * * *"} {
+		if _, err := ParseRecurrence(bad); err == nil {
+			t.Errorf("ParseRecurrence(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// Limits for what a context pack gathers before the budget is applied.
+const (
+	contextPredecessors = 5 // follows chain depth
+	contextComments     = 5 // most recent comments
+	minSectionTokens    = 40
+)
+
+// ContextSection is one part of a context pack.
+type ContextSection struct {
+	Kind      string `json:"kind"` // issue, parent, blocker, predecessor, comments, related
+	ID        string `json:"id,omitempty"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Tokens    int    `json:"tokens"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// ContextPack is everything an agent needs to pick up one issue, trimmed to
+// an approximate token budget.
+type ContextPack struct {
+	IssueID  string           `json:"issue_id"`
+	Budget   int              `json:"budget"`
+	Tokens   int              `json:"tokens"`
+	Sections []ContextSection `json:"sections"`
+	Omitted  []string         `json:"omitted"` // sections dropped to fit the budget
+}
+
+// EstimateTokens approximates the token count of s (about four characters
+// per token for English prose and code).
+func EstimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+// contextCandidate is a section before budgeting: full text plus a shorter
+// fallback used when the full text does not fit.
+type contextCandidate struct {
+	ContextSection
+	summary string
+}
+
+// BuildContext assembles a context pack for issue from all issues, in
+// priority order: the issue itself, its parent epic, open blockers,
+// predecessors on the follows chain, recent comments, and related issues.
+// Sections that do not fit the remaining budget fall back to a summary,
+// then are truncated, then omitted. The issue is always included.
+// A budget of 0 or less means no limit.
+func BuildContext(issue *model.Issue, all []*model.Issue, budget int) *ContextPack {
+	byID := make(map[string]*model.Issue, len(all))
+	for _, i := range all {
+		byID[i.ID] = i
+	}
+
+	var cands []contextCandidate
+	cands = append(cands, contextCandidate{ContextSection: ContextSection{
+		Kind: "issue", ID: issue.ID, Title: fmt.Sprintf("Issue %s: %s", issue.ID, issue.Title),
+		Content: issueHeader(issue) + "\n\n" + demoteHeadings(stripSections(issue.Body, "Comments", "History")),
+	}})
+
+	if parent, ok := byID[issue.Parent]; ok {
+		closed, total := 0, 0
+		for _, i := range all {
+			if i.Parent == parent.ID {
+				total++
+				if i.Status == model.StatusClosed {
+					closed++
+				}
+			}
+		}
+		desc := description(parent)
+		cands = append(cands, contextCandidate{
+			ContextSection: ContextSection{
+				Kind: "parent", ID: parent.ID,
+				Title:   fmt.Sprintf("Parent %s: %s (%d/%d children closed)", parent.ID, parent.Title, closed, total),
+				Content: desc,
+			},
+			summary: firstParagraph(desc),
+		})
+	}
+
+	for _, id := range issue.BlockedBy {
+		b, ok := byID[id]
+		if !ok || !b.IsOpen() {
+			continue
+		}
+		desc := description(b)
+		cands = append(cands, contextCandidate{
+			ContextSection: ContextSection{
+				Kind: "blocker", ID: b.ID,
+				Title:   fmt.Sprintf("Open blocker %s: %s [%s]", b.ID, b.Title, b.Status),
+				Content: desc,
+			},
+			summary: firstParagraph(desc),
+		})
+	}
+
+	seen := map[string]bool{issue.ID: true}
+	queue := append([]string(nil), issue.Follows...)
+	for n := 0; len(queue) > 0 && n < contextPredecessors; {
+		id := queue[0]
+		queue = queue[1:]
+		p, ok := byID[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		n++
+		title := fmt.Sprintf("Predecessor %s: %s [%s]", p.ID, p.Title, p.Status)
+		if p.CloseReason != "" {
+			title = fmt.Sprintf("Predecessor %s: %s (closed: %s)", p.ID, p.Title, p.CloseReason)
+		}
+		desc := description(p)
+		cands = append(cands, contextCandidate{
+			ContextSection: ContextSection{Kind: "predecessor", ID: p.ID, Title: title, Content: desc},
+			summary:        firstParagraph(desc),
+		})
+		queue = append(queue, p.Follows...)
+	}
+
+	if comments := recentComments(issue.Body, contextComments); len(comments) > 0 {
+		cands = append(cands, contextCandidate{
+			ContextSection: ContextSection{Kind: "comments", ID: issue.ID, Title: "Recent comments", Content: strings.Join(comments, "\n\n")},
+			summary:        comments[0],
+		})
+	}
+
+	related := append([]string(nil), issue.Related...)
+	sort.Strings(related)
+	for _, id := range related {
+		r, ok := byID[id]
+		if !ok {
+			continue
+		}
+		cands = append(cands, contextCandidate{
+			ContextSection: ContextSection{
+				Kind: "related", ID: r.ID,
+				Title:   fmt.Sprintf("Related %s: %s [%s]", r.ID, r.Title, r.Status),
+				Content: firstParagraph(description(r)),
+			},
+		})
+	}
+
+	pack := &ContextPack{IssueID: issue.ID, Budget: budget, Sections: []ContextSection{}, Omitted: []string{}}
+	for i, c := range cands {
+		sec, ok := fitSection(c, budget-pack.Tokens, budget > 0, i == 0)
+		if !ok {
+			pack.Omitted = append(pack.Omitted, c.Title)
+			continue
+		}
+		pack.Sections = append(pack.Sections, sec)
+		pack.Tokens += sec.Tokens
+	}
+	return pack
+}
+
+// fitSection fits a candidate into the remaining budget: full text, then
+// its summary, then a truncation. The first section is never dropped.
+func fitSection(c contextCandidate, remaining int, limited, required bool) (ContextSection, bool) {
+	sec := c.ContextSection
+	cost := func(content string) int { return EstimateTokens(sec.Title) + EstimateTokens(content) + 2 }
+
+	sec.Tokens = cost(sec.Content)
+	if !limited || sec.Tokens <= remaining {
+		return sec, true
+	}
+	if c.summary != "" && c.summary != sec.Content && cost(c.summary) <= remaining {
+		sec.Content, sec.Tokens, sec.Truncated = c.summary, cost(c.summary), true
+		return sec, true
+	}
+	if remaining < minSectionTokens && !required {
+		return sec, false
+	}
+	room := (remaining - EstimateTokens(sec.Title) - 2 - EstimateTokens(truncatedMarker)) * 4
+	if room < 0 {
+		room = 0
+	}
+	if room < len(sec.Content) {
+		for room > 0 && !utf8.RuneStart(sec.Content[room]) {
+			room--
+		}
+		sec.Content = strings.TrimRight(sec.Content[:room], " \n") + truncatedMarker
+	}
+	sec.Tokens, sec.Truncated = cost(sec.Content), true
+	return sec, true
+}
+
+const truncatedMarker = "\n[... truncated to fit the token budget]"
+
+// WriteContextMarkdown renders a context pack as markdown.
+func WriteContextMarkdown(w io.Writer, pack *ContextPack) {
+	fmt.Fprintf(w, "# Context for %s (nd context)\n\n", pack.IssueID)
+	if pack.Budget > 0 {
+		fmt.Fprintf(w, "~%d of %d tokens\n\n", pack.Tokens, pack.Budget)
+	}
+	for _, sec := range pack.Sections {
+		fmt.Fprintf(w, "## %s\n\n", sec.Title)
+		if content := strings.TrimSpace(sec.Content); content != "" {
+			fmt.Fprintln(w, content)
+			fmt.Fprintln(w)
+		}
+	}
+	if len(pack.Omitted) > 0 {
+		fmt.Fprintln(w, "## Omitted for budget")
+		fmt.Fprintln(w)
+		for _, t := range pack.Omitted {
+			fmt.Fprintf(w, "- %s\n", t)
+		}
+	}
+}
+
+func issueHeader(i *model.Issue) string {
+	parts := []string{
+		"Status: " + string(i.Status),
+		"Priority: " + i.Priority.Short(),
+		"Type: " + string(i.Type),
+	}
+	if i.Assignee != "" {
+		parts = append(parts, "Assignee: "+i.Assignee)
+	}
+	if len(i.Labels) > 0 {
+		parts = append(parts, "Labels: "+strings.Join(i.Labels, ", "))
+	}
+	if i.Due != "" {
+		parts = append(parts, "Due: "+i.Due)
+	}
+	if i.Estimate != "" {
+		parts = append(parts, "Estimate: "+i.Estimate)
+	}
+	return strings.Join(parts, " | ")
+}
+
+func description(i *model.Issue) string {
+	return strings.TrimSpace(model.Section(i.Body, "Description"))
+}
+
+func firstParagraph(s string) string {
+	s = strings.TrimSpace(s)
+	if p, _, ok := strings.Cut(s, "\n\n"); ok {
+		return p
+	}
+	return s
+}
+
+// stripSections removes the named ## sections, and any empty ones, from a
+// markdown body.
+func stripSections(body string, names ...string) string {
+	drop := make(map[string]bool, len(names))
+	for _, n := range names {
+		drop["## "+n] = true
+	}
+	var out, block []string
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		heading := strings.HasPrefix(block[0], "## ")
+		empty := strings.TrimSpace(strings.Join(block[1:], "\n")) == ""
+		if !(heading && (drop[strings.TrimRight(block[0], " ")] || empty)) {
+			out = append(out, block...)
+		}
+		block = nil
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+		}
+		block = append(block, line)
+	}
+	flush()
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// demoteHeadings pushes markdown headings down one level so an issue body
+// nests under its section heading.
+func demoteHeadings(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recentComments returns up to n comments from the Comments section,
+// newest first. Each comment starts at its "### <time> <author>" line.
+func recentComments(body string, n int) []string {
+	var comments []string
+	var cur []string
+	flush := func() {
+		if len(cur) > 0 {
+			comments = append(comments, strings.TrimSpace(strings.Join(cur, "\n")))
+		}
+		cur = nil
+	}
+	for _, line := range strings.Split(model.Section(body, "Comments"), "\n") {
+		if strings.HasPrefix(line, "### ") {
+			flush()
+			line = "#" + line
+		}
+		if cur != nil || strings.HasPrefix(line, "####") {
+			cur = append(cur, line)
+		}
+	}
+	flush()
+	var out []string
+	for i := len(comments) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, comments[i])
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func contextIssue(id, desc string) *model.Issue {
+	return &model.Issue{
+		ID: id, Title: "Issue " + id, Status: model.StatusOpen, Type: model.TypeTask,
+		Body: "## Description\n" + desc + "\n\n## Notes\n\n## History\n- created\n",
+	}
+}
+
+func TestBuildContextOrderAndBudget(t *testing.T) {
+	epic := contextIssue("E", "Epic goal.\n\n"+strings.Repeat("More epic detail. ", 30))
+	blocker := contextIssue("B", strings.Repeat("blocker detail ", 40))
+	pred := contextIssue("P", "Earlier work.")
+	pred.Status, pred.CloseReason = model.StatusClosed, "merged"
+	main := contextIssue("M", "Do the thing.")
+	main.Parent, main.BlockedBy, main.Follows = "E", []string{"B"}, []string{"P"}
+	main.Body += "\n## Comments\n\n### 2026-01-01T00:00:00Z alice\nold\n\n### 2026-01-02T00:00:00Z bob\nnew\n"
+	all := []*model.Issue{epic, blocker, pred, main}
+
+	full := BuildContext(main, all, 0)
+	var kinds []string
+	for _, s := range full.Sections {
+		kinds = append(kinds, s.Kind)
+	}
+	if got := strings.Join(kinds, ","); got != "issue,parent,blocker,predecessor,comments" {
+		t.Errorf("sections = %s", got)
+	}
+	body := full.Sections[0].Content
+	if strings.Contains(body, "History") || strings.Contains(body, "Notes") || strings.Contains(body, "Comments") {
+		t.Errorf("issue section should drop History, Comments, and empty sections:\n%s", body)
+	}
+	if !strings.Contains(full.Sections[3].Title, "closed: merged") {
+		t.Errorf("predecessor title = %q", full.Sections[3].Title)
+	}
+	if c := full.Sections[4].Content; strings.Index(c, "bob") > strings.Index(c, "alice") {
+		t.Errorf("comments should be newest first:\n%s", c)
+	}
+
+	small := BuildContext(main, all, 60)
+	if small.Tokens > 60 {
+		t.Errorf("pack uses %d tokens, budget 60", small.Tokens)
+	}
+	if small.Sections[0].Kind != "issue" || len(small.Omitted) == 0 {
+		t.Errorf("small pack: sections %d, omitted %v", len(small.Sections), small.Omitted)
+	}
+	for _, s := range small.Sections {
+		if s.Kind == "parent" && s.Content != "Epic goal." {
+			t.Errorf("parent should fall back to its first paragraph, got %q", s.Content)
+		}
+	}
+}
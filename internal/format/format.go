@@ -1,10 +1,10 @@
 package format
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/nd/internal/ui"
@@ -18,6 +18,7 @@ func Table(w io.Writer, issues []*model.Issue) {
 		return
 	}
 
+	now := time.Now()
 	for _, issue := range issues {
 		title := issue.Title
 		if len(title) > 60 {
@@ -47,6 +48,12 @@ func Table(w io.Writer, issues []*model.Issue) {
 		if len(issue.Labels) > 0 {
 			parts = append(parts, fmt.Sprintf("[%s]", strings.Join(issue.Labels, ", ")))
 		}
+		if issue.ACProgress.Total > 0 {
+			parts = append(parts, ui.RenderMuted(fmt.Sprintf("[AC %s]", issue.ACProgress)))
+		}
+		if due := dueMarker(issue, now); due != "" {
+			parts = append(parts, due)
+		}
 		parts = append(parts, fmt.Sprintf("- %s", title))
 
 		fmt.Fprintln(w, strings.Join(parts, " "))
@@ -95,6 +102,39 @@ func Detail(w io.Writer, issue *model.Issue) {
 	if issue.Parent != "" {
 		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Parent:"), issue.Parent)
 	}
+	if issue.ACProgress.Total > 0 {
+		fmt.Fprintf(w, "%s %s checked\n", ui.RenderAccent("Acceptance:"), issue.ACProgress)
+	}
+	if issue.DeferUntil != "" {
+		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Deferred until:"), issue.DeferUntil)
+	}
+	if issue.Due != "" {
+		due := issue.Due
+		switch now := time.Now(); {
+		case issue.IsOverdue(now):
+			due = ui.RenderOverdue(due + " (overdue)")
+		case issue.IsDueSoon(now):
+			due = ui.RenderDueSoon(due + " (due soon)")
+		}
+		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Due:"), due)
+	}
+	if issue.Recur != "" {
+		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Recurs:"), issue.Recur)
+	}
+	if issue.LeaseUntil != "" {
+		lease := issue.LeaseUntil
+		if issue.LeaseExpired(time.Now()) {
+			lease = ui.RenderOverdue(lease + " (expired)")
+		}
+		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Lease until:"), lease)
+	}
+	if issue.Estimate != "" || issue.Spent != "" {
+		effort := model.FormatEffort(issue.SpentDuration()) + " spent"
+		if issue.Estimate != "" {
+			effort += " of " + issue.Estimate + " estimated"
+		}
+		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Effort:"), effort)
+	}
 	if len(issue.Blocks) > 0 {
 		fmt.Fprintf(w, "%s %s\n", ui.RenderAccent("Blocks:"), strings.Join(issue.Blocks, ", "))
 	}
@@ -117,18 +157,16 @@ func Detail(w io.Writer, issue *model.Issue) {
 	}
 }
 
-// JSON outputs issues as JSON.
-func JSON(w io.Writer, issues []*model.Issue) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(issues)
-}
-
-// JSONSingle outputs a single issue as JSON.
-func JSONSingle(w io.Writer, issue *model.Issue) error {
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", "  ")
-	return enc.Encode(issue)
+// dueMarker flags an overdue or due-soon issue; other issues get "".
+func dueMarker(issue *model.Issue, now time.Time) string {
+	switch {
+	case issue.IsOverdue(now):
+		return ui.RenderOverdue(fmt.Sprintf("[overdue %s]", issue.Due))
+	case issue.IsDueSoon(now):
+		return ui.RenderDueSoon(fmt.Sprintf("[due %s]", issue.Due))
+	default:
+		return ""
+	}
 }
 
 // Short renders a one-line summary of an issue.
@@ -0,0 +1,232 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// JSONSchemaVersion is the version of the --json output contract. It is
+// bumped when a field is renamed or removed, or its meaning changes; adding
+// fields does not bump it.
+const JSONSchemaVersion = 1
+
+// IssueJSON is the JSON form of an issue. Field names match the frontmatter.
+// Relationship lists are always arrays, never null; optional scalars are
+// omitted when empty.
+type IssueJSON struct {
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Status       string         `json:"status"`
+	Priority     int            `json:"priority"`
+	Type         string         `json:"type"`
+	Assignee     string         `json:"assignee,omitempty"`
+	Labels       []string       `json:"labels"`
+	Parent       string         `json:"parent,omitempty"`
+	Blocks       []string       `json:"blocks"`
+	BlockedBy    []string       `json:"blocked_by"`
+	WasBlockedBy []string       `json:"was_blocked_by"`
+	Related      []string       `json:"related"`
+	Follows      []string       `json:"follows"`
+	LedTo        []string       `json:"led_to"`
+	CreatedAt    string         `json:"created_at"`
+	CreatedBy    string         `json:"created_by"`
+	UpdatedAt    string         `json:"updated_at"`
+	DeferUntil   string         `json:"defer_until,omitempty"`
+	Recur        string         `json:"recur,omitempty"`
+	Due          string         `json:"due,omitempty"`
+	Estimate     string         `json:"estimate,omitempty"`
+	Spent        string         `json:"spent,omitempty"`
+	LeaseUntil   string         `json:"lease_until,omitempty"`
+	PlanKey      string         `json:"plan_key,omitempty"`
+	ClosedAt     string         `json:"closed_at,omitempty"`
+	CloseReason  string         `json:"close_reason,omitempty"`
+	ContentHash  string         `json:"content_hash,omitempty"`
+	Acceptance   AcceptanceJSON `json:"acceptance"`
+	Sections     []SectionJSON  `json:"sections"` // body sections other than Comments and History
+	Comments     []CommentJSON  `json:"comments"`
+	History      []HistoryJSON  `json:"history"`
+}
+
+// AcceptanceJSON counts the acceptance-criteria checkboxes.
+type AcceptanceJSON struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// SectionJSON is one ## section of an issue body.
+type SectionJSON struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// CommentJSON is one comment from the Comments section.
+type CommentJSON struct {
+	At     string `json:"at,omitempty"`
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+// HistoryJSON is one line of the History section.
+type HistoryJSON struct {
+	At   string `json:"at,omitempty"`
+	Text string `json:"text"`
+}
+
+// NodeJSON is a node of a tree output (epic tree, path, graph). Issue
+// details are in the envelope's issues list, keyed by ID.
+type NodeJSON struct {
+	ID       string     `json:"id"`
+	Children []NodeJSON `json:"children"`
+}
+
+// ListJSON is the envelope for commands that output a list of issues.
+type ListJSON struct {
+	SchemaVersion int         `json:"schema_version"`
+	Count         int         `json:"count"`
+	Issues        []IssueJSON `json:"issues"`
+}
+
+// SingleJSON is the envelope for commands that output one issue.
+type SingleJSON struct {
+	SchemaVersion int       `json:"schema_version"`
+	Issue         IssueJSON `json:"issue"`
+}
+
+// TreeJSON is the envelope for commands that output a tree. Issues holds
+// each issue in the tree once, in first-seen order.
+type TreeJSON struct {
+	SchemaVersion int         `json:"schema_version"`
+	Count         int         `json:"count"`
+	Issues        []IssueJSON `json:"issues"`
+	Roots         []NodeJSON  `json:"roots"`
+}
+
+// ToJSON converts an issue to its JSON form.
+func ToJSON(issue *model.Issue) IssueJSON {
+	out := IssueJSON{
+		ID:           issue.ID,
+		Title:        issue.Title,
+		Status:       string(issue.Status),
+		Priority:     int(issue.Priority),
+		Type:         string(issue.Type),
+		Assignee:     issue.Assignee,
+		Labels:       nonNil(issue.Labels),
+		Parent:       issue.Parent,
+		Blocks:       nonNil(issue.Blocks),
+		BlockedBy:    nonNil(issue.BlockedBy),
+		WasBlockedBy: nonNil(issue.WasBlockedBy),
+		Related:      nonNil(issue.Related),
+		Follows:      nonNil(issue.Follows),
+		LedTo:        nonNil(issue.LedTo),
+		CreatedAt:    jsonTime(issue.CreatedAt),
+		CreatedBy:    issue.CreatedBy,
+		UpdatedAt:    jsonTime(issue.UpdatedAt),
+		DeferUntil:   issue.DeferUntil,
+		Recur:        issue.Recur,
+		Due:          issue.Due,
+		Estimate:     issue.Estimate,
+		Spent:        issue.Spent,
+		LeaseUntil:   issue.LeaseUntil,
+		PlanKey:      issue.PlanKey,
+		ClosedAt:     issue.ClosedAt,
+		CloseReason:  issue.CloseReason,
+		ContentHash:  issue.ContentHash,
+		Acceptance:   AcceptanceJSON{Done: issue.ACProgress.Done, Total: issue.ACProgress.Total},
+		Sections:     []SectionJSON{},
+		Comments:     []CommentJSON{},
+		History:      []HistoryJSON{},
+	}
+	for _, s := range model.Sections(issue.Body) {
+		if s.Name == "Comments" || s.Name == "History" {
+			continue
+		}
+		out.Sections = append(out.Sections, SectionJSON{Name: s.Name, Content: s.Content})
+	}
+	for _, c := range model.Comments(issue.Body) {
+		out.Comments = append(out.Comments, CommentJSON{At: c.At, Author: c.Author, Text: c.Text})
+	}
+	for _, h := range model.History(issue.Body) {
+		out.History = append(out.History, HistoryJSON{At: h.At, Text: h.Text})
+	}
+	return out
+}
+
+// ToJSONList converts issues to their JSON form; the result is never nil.
+func ToJSONList(issues []*model.Issue) []IssueJSON {
+	out := make([]IssueJSON, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, ToJSON(issue))
+	}
+	return out
+}
+
+// JSON outputs issues in a list envelope.
+func JSON(w io.Writer, issues []*model.Issue) error {
+	return encodeJSON(w, ListJSON{SchemaVersion: JSONSchemaVersion, Count: len(issues), Issues: ToJSONList(issues)})
+}
+
+// JSONSingle outputs a single issue in an envelope.
+func JSONSingle(w io.Writer, issue *model.Issue) error {
+	return encodeJSON(w, SingleJSON{SchemaVersion: JSONSchemaVersion, Issue: ToJSON(issue)})
+}
+
+// JSONTree outputs a tree envelope. issues should hold every issue named in
+// roots; duplicates are dropped. Children are sorted by ID so output is
+// stable.
+func JSONTree(w io.Writer, issues []*model.Issue, roots []NodeJSON) error {
+	seen := make(map[string]bool, len(issues))
+	var unique []*model.Issue
+	for _, issue := range issues {
+		if !seen[issue.ID] {
+			seen[issue.ID] = true
+			unique = append(unique, issue)
+		}
+	}
+	if roots == nil {
+		roots = []NodeJSON{}
+	}
+	sortNodes(roots)
+	return encodeJSON(w, TreeJSON{
+		SchemaVersion: JSONSchemaVersion,
+		Count:         len(unique),
+		Issues:        ToJSONList(unique),
+		Roots:         roots,
+	})
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false) // history lines contain "->"
+	return enc.Encode(v)
+}
+
+func sortNodes(nodes []NodeJSON) {
+	for i := range nodes {
+		if nodes[i].Children == nil {
+			nodes[i].Children = []NodeJSON{}
+		}
+		sort.SliceStable(nodes[i].Children, func(a, b int) bool {
+			return nodes[i].Children[a].ID < nodes[i].Children[b].ID
+		})
+		sortNodes(nodes[i].Children)
+	}
+}
+
+func jsonTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
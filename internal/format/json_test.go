@@ -0,0 +1,95 @@
+package format
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func jsonIssue(id string) *model.Issue {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	return &model.Issue{
+		ID: id, Title: "Issue " + id, Status: model.StatusOpen, Priority: 2, Type: model.TypeTask,
+		CreatedAt: at, CreatedBy: "tester", UpdatedAt: at,
+		ContentHash: "sha256:0000",
+		Body:        "## Description\nShort.\n\n## Acceptance Criteria\n\n## History\n- 2026-03-01T09:30:00Z created\n\n## Links\n",
+	}
+}
+
+// checkGolden compares got with testdata/name; go test -update rewrites it.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test ./internal/format -update)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s mismatch\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+func TestJSONSingleGolden(t *testing.T) {
+	issue := jsonIssue("TST-a1b2")
+	issue.Status, issue.Priority, issue.Type = model.StatusInProgress, 1, model.TypeBug
+	issue.Assignee, issue.Labels, issue.Parent = "alice", []string{"backend"}, "TST-e001"
+	issue.BlockedBy, issue.Related = []string{"TST-c3d4"}, []string{"TST-f5f5"}
+	issue.Due, issue.Estimate, issue.Spent = "2026-03-10", "4h", "1h30m"
+	issue.ACProgress = model.Progress{Done: 1, Total: 2}
+	issue.Body = "## Description\nFix the crash.\n\n## Acceptance Criteria\n- [x] repro\n- [ ] fix\n\n" +
+		"## History\n- 2026-03-01T09:30:00Z status: open -> in_progress\n\n## Links\n- Parent: [[TST-e001]]\n\n" +
+		"## Comments\n\n### 2026-03-02T10:00:00Z bob\nSeen on <main> & release.\n"
+
+	var buf bytes.Buffer
+	if err := JSONSingle(&buf, issue); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "single.golden", buf.Bytes())
+}
+
+func TestJSONListGolden(t *testing.T) {
+	closed := jsonIssue("TST-c3d4")
+	closed.Status, closed.ClosedAt, closed.CloseReason = model.StatusClosed, "2026-03-02T00:00:00Z", "done"
+	closed.Blocks = []string{"TST-a1b2"}
+
+	var buf bytes.Buffer
+	if err := JSON(&buf, []*model.Issue{jsonIssue("TST-a1b2"), closed}); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "list.golden", buf.Bytes())
+
+	buf.Reset()
+	if err := JSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "list_empty.golden", buf.Bytes())
+}
+
+func TestJSONTreeGolden(t *testing.T) {
+	epic, b, a := jsonIssue("TST-e001"), jsonIssue("TST-b002"), jsonIssue("TST-a001")
+	epic.Type = model.TypeEpic
+	// b appears twice in the tree but once in issues; children sort by ID.
+	roots := []NodeJSON{{ID: epic.ID, Children: []NodeJSON{
+		{ID: b.ID},
+		{ID: a.ID, Children: []NodeJSON{{ID: b.ID}}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := JSONTree(&buf, []*model.Issue{epic, b, a, b}, roots); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "tree.golden", buf.Bytes())
+}
@@ -3,60 +3,334 @@ package format
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/RamXX/nd/internal/model"
 )
 
-// PrimeContext outputs a structured summary suitable for AI context injection.
-func PrimeContext(w io.Writer, issues []*model.Issue, ready, blocked []*model.Issue) {
-	fmt.Fprintln(w, "# Project Status (nd prime)")
-	fmt.Fprintln(w)
+// PrimeOptions selects what a prime report covers. Sections are the names
+// in store.PrimeSections, in display order.
+type PrimeOptions struct {
+	Sections   []string
+	Limit      int       // items per section; 0 means all
+	Actor      string    // who is priming
+	Mine       bool      // scope ready and in-progress work to the actor
+	Since      time.Time // the actor's previous prime; zero when never
+	ClosedDays int       // recently_closed window
+	StaleDays  int       // stale threshold
+	Rules      []string  // plain-English workflow rules
+	Now        time.Time
+}
+
+// PrimeSummary holds the counts on the report's totals line.
+type PrimeSummary struct {
+	Total      int `json:"total"`
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Closed     int `json:"closed"`
+	Overdue    int `json:"overdue"`
+	DueSoon    int `json:"due_soon"`
+}
+
+// PrimeItem is one issue line in a prime section.
+type PrimeItem struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  string   `json:"priority"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Due       string   `json:"due,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+	Detail    string   `json:"detail,omitempty"` // section-specific: change kind, close reason, progress, age
+}
+
+// PrimeSection is one titled list in a prime report.
+type PrimeSection struct {
+	Name  string      `json:"name"`
+	Title string      `json:"title"`
+	Items []PrimeItem `json:"items,omitempty"`
+	Lines []string    `json:"lines,omitempty"` // rules and notes
+	More  int         `json:"more,omitempty"`  // items cut by the limit
+}
+
+// PrimeReport is the profile-driven output of nd prime.
+type PrimeReport struct {
+	Actor    string         `json:"actor,omitempty"`
+	Since    string         `json:"since,omitempty"`
+	Summary  *PrimeSummary  `json:"summary,omitempty"`
+	Sections []PrimeSection `json:"sections"`
+}
+
+// BuildPrime assembles a prime report from all issues. ready and blocked
+// come from the dependency graph.
+func BuildPrime(issues, ready, blocked []*model.Issue, opts PrimeOptions) *PrimeReport {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	report := &PrimeReport{Actor: opts.Actor, Sections: []PrimeSection{}}
+	if !opts.Since.IsZero() {
+		report.Since = opts.Since.UTC().Format(time.RFC3339)
+	}
+	overdue, dueSoon := DueBuckets(issues, opts.Now)
+
+	for _, name := range opts.Sections {
+		switch name {
+		case "summary":
+			sum := &PrimeSummary{Total: len(issues), Blocked: len(blocked), Overdue: len(overdue), DueSoon: len(dueSoon)}
+			for _, i := range issues {
+				switch i.Status {
+				case model.StatusOpen:
+					sum.Open++
+				case model.StatusInProgress:
+					sum.InProgress++
+				case model.StatusClosed:
+					sum.Closed++
+				}
+			}
+			report.Summary = sum
+
+		case "changed":
+			report.Sections = append(report.Sections, primeChanged(issues, opts))
 
-	// Summary counts.
-	open, inProgress, closed := 0, 0, 0
+		case "overdue":
+			report.Sections = append(report.Sections,
+				primeSection("overdue", "Overdue", overdue, opts.Limit, nil),
+				primeSection("due_soon", "Due Soon", dueSoon, opts.Limit, nil))
+
+		case "ready":
+			var list []*model.Issue
+			for _, i := range ready {
+				if !opts.Mine || i.Assignee == "" || i.Assignee == opts.Actor {
+					list = append(list, i)
+				}
+			}
+			sort.SliceStable(list, func(a, b int) bool {
+				if list[a].Priority != list[b].Priority {
+					return list[a].Priority < list[b].Priority
+				}
+				return list[a].ID < list[b].ID
+			})
+			report.Sections = append(report.Sections, primeSection("ready", "Ready (actionable)", list, opts.Limit, nil))
+
+		case "blocked":
+			list := append([]*model.Issue(nil), blocked...)
+			sort.SliceStable(list, func(a, b int) bool { return list[a].ID < list[b].ID })
+			report.Sections = append(report.Sections, primeSection("blocked", "Blocked", list, opts.Limit, nil))
+
+		case "in_progress":
+			var list []*model.Issue
+			for _, i := range issues {
+				if i.Status == model.StatusInProgress && (!opts.Mine || i.Assignee == opts.Actor) {
+					list = append(list, i)
+				}
+			}
+			report.Sections = append(report.Sections, primeSection("in_progress", "In Progress", list, opts.Limit, nil))
+
+		case "recently_closed":
+			cutoff := opts.Now.AddDate(0, 0, -opts.ClosedDays).UTC().Format(time.RFC3339)
+			var list []*model.Issue
+			for _, i := range issues {
+				if i.Status == model.StatusClosed && i.ClosedAt >= cutoff {
+					list = append(list, i)
+				}
+			}
+			sort.SliceStable(list, func(a, b int) bool { return list[a].ClosedAt > list[b].ClosedAt })
+			title := fmt.Sprintf("Recently Closed (%dd)", opts.ClosedDays)
+			report.Sections = append(report.Sections, primeSection("recently_closed", title, list, opts.Limit, func(i *model.Issue) string {
+				closed := i.ClosedAt
+				if len(closed) > 10 {
+					closed = closed[:10]
+				}
+				if i.CloseReason == "" {
+					return "closed " + closed
+				}
+				return "closed " + closed + ": " + i.CloseReason
+			}))
+
+		case "epics":
+			report.Sections = append(report.Sections, primeEpics(issues, opts.Limit))
+
+		case "stale":
+			cutoff := opts.Now.AddDate(0, 0, -opts.StaleDays)
+			var list []*model.Issue
+			for _, i := range issues {
+				if i.IsOpen() && i.UpdatedAt.Before(cutoff) {
+					list = append(list, i)
+				}
+			}
+			sort.SliceStable(list, func(a, b int) bool { return list[a].UpdatedAt.Before(list[b].UpdatedAt) })
+			title := fmt.Sprintf("Stale (no update in %dd)", opts.StaleDays)
+			report.Sections = append(report.Sections, primeSection("stale", title, list, opts.Limit, func(i *model.Issue) string {
+				return fmt.Sprintf("updated %dd ago", int(opts.Now.Sub(i.UpdatedAt).Hours()/24))
+			}))
+
+		case "rules":
+			report.Sections = append(report.Sections, PrimeSection{Name: "rules", Title: "Workflow Rules", Lines: opts.Rules})
+		}
+	}
+	return report
+}
+
+// primeSection converts issues into a section, keeping the first limit.
+func primeSection(name, title string, list []*model.Issue, limit int, detail func(*model.Issue) string) PrimeSection {
+	sec := PrimeSection{Name: name, Title: title}
+	if limit > 0 && len(list) > limit {
+		sec.More = len(list) - limit
+		list = list[:limit]
+	}
+	for _, i := range list {
+		item := PrimeItem{
+			ID: i.ID, Title: i.Title, Status: string(i.Status), Priority: i.Priority.Short(),
+			Assignee: i.Assignee, Due: i.Due, BlockedBy: i.BlockedBy,
+		}
+		if detail != nil {
+			item.Detail = detail(i)
+		}
+		sec.Items = append(sec.Items, item)
+	}
+	return sec
+}
+
+// primeChanged lists issues updated since the actor's previous prime,
+// most recent first. Timestamps have one-second resolution, so an update in
+// the same second as the prime counts as a change.
+func primeChanged(issues []*model.Issue, opts PrimeOptions) PrimeSection {
+	if opts.Since.IsZero() {
+		return PrimeSection{Name: "changed", Title: "Changed Since Last Prime",
+			Lines: []string{"No previous prime recorded; everything below is new to you."}}
+	}
+	var list []*model.Issue
 	for _, i := range issues {
-		switch i.Status {
-		case model.StatusOpen:
-			open++
-		case model.StatusInProgress:
-			inProgress++
-		case model.StatusClosed:
-			closed++
+		if !i.UpdatedAt.Before(opts.Since) {
+			list = append(list, i)
 		}
 	}
-	fmt.Fprintf(w, "Total: %d | Open: %d | In Progress: %d | Blocked: %d | Closed: %d\n\n",
-		len(issues), open, inProgress, len(blocked), closed)
+	sort.SliceStable(list, func(a, b int) bool { return list[a].UpdatedAt.After(list[b].UpdatedAt) })
+	since := opts.Since.UTC().Format(time.RFC3339)
+	return primeSection("changed", "Changed Since Last Prime ("+since+")", list, opts.Limit, func(i *model.Issue) string {
+		switch {
+		case !i.CreatedAt.Before(opts.Since):
+			return "new"
+		case i.Status == model.StatusClosed && i.ClosedAt >= since:
+			return "closed"
+		default:
+			return "updated"
+		}
+	})
+}
 
-	// Ready work.
-	if len(ready) > 0 {
-		fmt.Fprintln(w, "## Ready (actionable)")
-		for _, r := range ready {
-			fmt.Fprintf(w, "- %s [%s] %s (%s)\n", r.ID, r.Status, r.Title, r.Priority.Short())
+// primeEpics lists epics that are not closed and have children, with how
+// many of those children are closed.
+func primeEpics(issues []*model.Issue, limit int) PrimeSection {
+	closed, total := make(map[string]int), make(map[string]int)
+	for _, i := range issues {
+		if i.Parent == "" {
+			continue
+		}
+		total[i.Parent]++
+		if i.Status == model.StatusClosed {
+			closed[i.Parent]++
 		}
-		fmt.Fprintln(w)
 	}
+	var list []*model.Issue
+	for _, i := range issues {
+		if i.Type == model.TypeEpic && i.IsOpen() && total[i.ID] > 0 {
+			list = append(list, i)
+		}
+	}
+	return primeSection("epics", "Epics In Flight", list, limit, func(i *model.Issue) string {
+		return fmt.Sprintf("%d/%d children closed", closed[i.ID], total[i.ID])
+	})
+}
+
+// WritePrime renders a prime report as markdown for AI context injection.
+// Ready, blocked, overdue, and due-soon sections are left out when empty;
+// the others print (none).
+func WritePrime(w io.Writer, report *PrimeReport) {
+	fmt.Fprintln(w, "# Project Status (nd prime)")
+	fmt.Fprintln(w)
 
-	// Blocked work.
-	if len(blocked) > 0 {
-		fmt.Fprintln(w, "## Blocked")
-		for _, b := range blocked {
-			deps := strings.Join(b.BlockedBy, ", ")
-			fmt.Fprintf(w, "- %s %s (blocked by: %s)\n", b.ID, b.Title, deps)
+	if sum := report.Summary; sum != nil {
+		fmt.Fprintf(w, "Total: %d | Open: %d | In Progress: %d | Blocked: %d | Closed: %d\n",
+			sum.Total, sum.Open, sum.InProgress, sum.Blocked, sum.Closed)
+		// Deadlines come first so agents pick up late work before new work.
+		if sum.Overdue > 0 || sum.DueSoon > 0 {
+			fmt.Fprintf(w, "Overdue: %d | Due within %s: %d\n", sum.Overdue, dueSoonLabel(), sum.DueSoon)
 		}
 		fmt.Fprintln(w)
 	}
 
-	// In-progress work.
-	fmt.Fprintln(w, "## In Progress")
-	found := false
+	var shown []PrimeSection
+	for _, sec := range report.Sections {
+		switch sec.Name {
+		case "overdue", "due_soon", "ready", "blocked":
+			if len(sec.Items) == 0 {
+				continue
+			}
+		}
+		shown = append(shown, sec)
+	}
+	for n, sec := range shown {
+		fmt.Fprintf(w, "## %s\n", sec.Title)
+		for _, line := range sec.Lines {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+		for _, i := range sec.Items {
+			fmt.Fprintln(w, primeLine(sec.Name, i))
+		}
+		if sec.More > 0 {
+			fmt.Fprintf(w, "- ... and %d more\n", sec.More)
+		}
+		if len(sec.Items) == 0 && len(sec.Lines) == 0 {
+			fmt.Fprintln(w, "(none)")
+		}
+		if n < len(shown)-1 {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+func primeLine(section string, i PrimeItem) string {
+	switch section {
+	case "overdue", "due_soon":
+		return fmt.Sprintf("- %s [%s] %s (%s, due %s)", i.ID, i.Status, i.Title, i.Priority, i.Due)
+	case "ready":
+		return fmt.Sprintf("- %s [%s] %s (%s)", i.ID, i.Status, i.Title, i.Priority)
+	case "blocked":
+		return fmt.Sprintf("- %s %s (blocked by: %s)", i.ID, i.Title, strings.Join(i.BlockedBy, ", "))
+	case "in_progress":
+		return fmt.Sprintf("- %s %s (assigned: %s)", i.ID, i.Title, i.Assignee)
+	default:
+		return fmt.Sprintf("- %s [%s] %s (%s, %s)", i.ID, i.Status, i.Title, i.Priority, i.Detail)
+	}
+}
+
+// DueBuckets splits open issues into overdue and due-soon lists, each
+// ordered by deadline.
+func DueBuckets(issues []*model.Issue, now time.Time) (overdue, dueSoon []*model.Issue) {
 	for _, i := range issues {
-		if i.Status == model.StatusInProgress {
-			fmt.Fprintf(w, "- %s %s (assigned: %s)\n", i.ID, i.Title, i.Assignee)
-			found = true
+		switch {
+		case i.IsOverdue(now):
+			overdue = append(overdue, i)
+		case i.IsDueSoon(now):
+			dueSoon = append(dueSoon, i)
 		}
 	}
-	if !found {
-		fmt.Fprintln(w, "(none)")
+	byDeadline := func(list []*model.Issue) {
+		sort.SliceStable(list, func(a, b int) bool {
+			da, _ := list[a].Deadline()
+			db, _ := list[b].Deadline()
+			return da.Before(db)
+		})
 	}
+	byDeadline(overdue)
+	byDeadline(dueSoon)
+	return overdue, dueSoon
+}
+
+func dueSoonLabel() string {
+	return fmt.Sprintf("%dd", int(model.DueSoonWindow.Hours()/24))
 }
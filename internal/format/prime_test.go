@@ -0,0 +1,97 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func primeIssue(id string, status model.Status, prio model.Priority, updated time.Time) *model.Issue {
+	return &model.Issue{
+		ID: id, Title: "Issue " + id, Status: status, Priority: prio, Type: model.TypeTask,
+		CreatedAt: updated, UpdatedAt: updated,
+	}
+}
+
+func TestWritePrimeClassic(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	a := primeIssue("A", model.StatusOpen, 2, now)
+	b := primeIssue("B", model.StatusOpen, 0, now)
+	c := primeIssue("C", model.StatusInProgress, 1, now)
+	c.Assignee = "alice"
+	all := []*model.Issue{a, b, c}
+
+	report := BuildPrime(all, []*model.Issue{a, b}, nil, PrimeOptions{
+		Sections: []string{"summary", "overdue", "ready", "blocked", "in_progress"},
+		Now:      now,
+	})
+	var buf bytes.Buffer
+	WritePrime(&buf, report)
+	want := `# Project Status (nd prime)
+
+Total: 3 | Open: 2 | In Progress: 1 | Blocked: 0 | Closed: 0
+
+## Ready (actionable)
+- B [open] Issue B (P0)
+- A [open] Issue A (P2)
+
+## In Progress
+- C Issue C (assigned: alice)
+`
+	if buf.String() != want {
+		t.Errorf("output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestBuildPrimeDeltaAndLimits(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-2 * time.Hour)
+	old := primeIssue("OLD", model.StatusOpen, 2, now.AddDate(0, 0, -30))
+	fresh := primeIssue("NEW", model.StatusOpen, 2, now.Add(-time.Hour))
+	done := primeIssue("DONE", model.StatusClosed, 2, now.AddDate(0, 0, -3))
+	done.CreatedAt = now.AddDate(0, 0, -10)
+	done.UpdatedAt = now.Add(-time.Hour)
+	done.ClosedAt, done.CloseReason = now.Add(-time.Hour).Format(time.RFC3339), "shipped"
+	mine := primeIssue("MINE", model.StatusOpen, 1, now)
+	mine.Assignee = "alice"
+	theirs := primeIssue("THEIRS", model.StatusOpen, 0, now)
+	theirs.Assignee = "bob"
+	all := []*model.Issue{old, fresh, done, mine, theirs}
+
+	report := BuildPrime(all, []*model.Issue{old, fresh, mine, theirs}, nil, PrimeOptions{
+		Sections:   []string{"changed", "ready", "recently_closed", "stale"},
+		Limit:      2,
+		Actor:      "alice",
+		Mine:       true,
+		Since:      since,
+		ClosedDays: 7,
+		StaleDays:  14,
+		Now:        now,
+	})
+	got := make(map[string]PrimeSection)
+	for _, sec := range report.Sections {
+		got[sec.Name] = sec
+	}
+
+	var changed []string
+	for _, i := range got["changed"].Items {
+		changed = append(changed, i.ID+":"+i.Detail)
+	}
+	if strings.Join(changed, ",") != "MINE:new,THEIRS:new" || got["changed"].More != 2 {
+		t.Errorf("changed = %v (+%d)", changed, got["changed"].More)
+	}
+
+	ready := got["ready"]
+	if len(ready.Items) != 2 || ready.Items[0].ID != "MINE" || ready.More != 1 {
+		t.Errorf("ready should skip bob's issue, sort by priority, and cap at 2: %+v", ready)
+	}
+	if items := got["recently_closed"].Items; len(items) != 1 || !strings.Contains(items[0].Detail, "shipped") {
+		t.Errorf("recently_closed = %+v", items)
+	}
+	if items := got["stale"].Items; len(items) != 1 || items[0].ID != "OLD" {
+		t.Errorf("stale = %+v", items)
+	}
+}
@@ -0,0 +1,203 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// ScheduleItem is one open issue placed on the dependency schedule. Times
+// are offsets of work from now, as durations of effort.
+type ScheduleItem struct {
+	Issue          *model.Issue
+	Weight         time.Duration // remaining effort; a working day when unestimated
+	Estimated      bool          // Weight comes from the issue's estimate
+	EarliestStart  time.Duration
+	EarliestFinish time.Duration
+	LatestStart    time.Duration
+	LatestFinish   time.Duration
+	Slack          time.Duration // how much the issue can slip without delaying the end
+}
+
+// Critical reports whether the issue is on a zero-slack chain.
+func (s *ScheduleItem) Critical() bool { return s.Slack == 0 }
+
+// Schedule is the result of a critical path analysis.
+type Schedule struct {
+	Chain     []*ScheduleItem // the critical chain, in dependency order
+	Items     []*ScheduleItem // every open issue, least slack first
+	Length    time.Duration   // effort along the critical chain
+	Remaining time.Duration   // effort of all open issues in scope
+}
+
+// Weight returns the effort an issue stands for in scheduling: its estimate
+// minus time already spent (at least an hour while it is open), or one
+// working day when it has no estimate.
+func Weight(issue *model.Issue) (time.Duration, bool) {
+	est := issue.EstimateDuration()
+	if est == 0 {
+		return model.EffortDay, false
+	}
+	if issue.Status == model.StatusClosed {
+		return est, true
+	}
+	if left := est - issue.SpentDuration(); left > time.Hour {
+		return left, true
+	}
+	return time.Hour, true
+}
+
+// CriticalPath schedules the open issues in scope (all issues when scope is
+// nil) over their blocked_by edges and finds the longest chain of remaining
+// work. Edges to issues outside the scope or already closed are ignored.
+// Returns an error naming the issues involved when the scope has a cycle.
+func (g *Graph) CriticalPath(scope map[string]bool) (*Schedule, error) {
+	items := make(map[string]*ScheduleItem)
+	for id, issue := range g.nodes {
+		if issue.Status == model.StatusClosed || (scope != nil && !scope[id]) {
+			continue
+		}
+		w, est := Weight(issue)
+		items[id] = &ScheduleItem{Issue: issue, Weight: w, Estimated: est}
+	}
+
+	preds := make(map[string][]string)
+	succs := make(map[string][]string)
+	indeg := make(map[string]int)
+	for id := range items {
+		for _, dep := range g.reverse[id] {
+			if _, ok := items[dep]; ok && dep != id {
+				preds[id] = append(preds[id], dep)
+				succs[dep] = append(succs[dep], id)
+				indeg[id]++
+			}
+		}
+	}
+
+	// Kahn's algorithm, visiting IDs in sorted order for stable output.
+	var queue, order []string
+	for id := range items {
+		if indeg[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		next := append([]string(nil), succs[id]...)
+		sort.Strings(next)
+		for _, s := range next {
+			if indeg[s]--; indeg[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+	if len(order) < len(items) {
+		var stuck []string
+		for id := range items {
+			if indeg[id] > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		sort.Strings(stuck)
+		return nil, fmt.Errorf("dependency cycle among %s; run nd dep cycles", strings.Join(stuck, ", "))
+	}
+
+	sched := &Schedule{}
+	for _, id := range order {
+		it := items[id]
+		for _, p := range preds[id] {
+			if f := items[p].EarliestFinish; f > it.EarliestStart {
+				it.EarliestStart = f
+			}
+		}
+		it.EarliestFinish = it.EarliestStart + it.Weight
+		if it.EarliestFinish > sched.Length {
+			sched.Length = it.EarliestFinish
+		}
+		sched.Remaining += it.Weight
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		it := items[order[i]]
+		it.LatestFinish = sched.Length
+		for _, s := range succs[order[i]] {
+			if ls := items[s].LatestStart; ls < it.LatestFinish {
+				it.LatestFinish = ls
+			}
+		}
+		it.LatestStart = it.LatestFinish - it.Weight
+		it.Slack = it.LatestStart - it.EarliestStart
+	}
+
+	for _, id := range order {
+		sched.Items = append(sched.Items, items[id])
+	}
+	sort.SliceStable(sched.Items, func(i, j int) bool {
+		a, b := sched.Items[i], sched.Items[j]
+		if a.Slack != b.Slack {
+			return a.Slack < b.Slack
+		}
+		return a.EarliestStart < b.EarliestStart
+	})
+
+	// Walk the chain back from the critical item that finishes last.
+	var cur *ScheduleItem
+	for _, it := range sched.Items {
+		if it.Critical() && it.EarliestFinish == sched.Length && (cur == nil || it.Issue.ID < cur.Issue.ID) {
+			cur = it
+		}
+	}
+	for cur != nil {
+		sched.Chain = append([]*ScheduleItem{cur}, sched.Chain...)
+		var prev *ScheduleItem
+		ps := append([]string(nil), preds[cur.Issue.ID]...)
+		sort.Strings(ps)
+		for _, p := range ps {
+			if it := items[p]; it.Critical() && it.EarliestFinish == cur.EarliestStart {
+				prev = it
+				break
+			}
+		}
+		cur = prev
+	}
+	return sched, nil
+}
+
+// Throughput measures the effort closed per calendar day over the window
+// ending at now, weighing each closed issue like Weight does. closed is the
+// number of issues counted; a zero rate means there is no recent history.
+func Throughput(issues []*model.Issue, now time.Time, window time.Duration) (perDay time.Duration, closed int) {
+	if window <= 0 {
+		return 0, 0
+	}
+	var total time.Duration
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed || issue.ClosedAt == "" {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, issue.ClosedAt)
+		if err != nil || at.After(now) || now.Sub(at) > window {
+			continue
+		}
+		w, _ := Weight(issue)
+		total += w
+		closed++
+	}
+	days := window.Hours() / 24
+	return time.Duration(float64(total) / days), closed
+}
+
+// ProjectFinish estimates when remaining effort completes at the given daily
+// rate. Returns false when the rate is zero.
+func ProjectFinish(now time.Time, remaining, perDay time.Duration) (time.Time, bool) {
+	if perDay <= 0 {
+		return time.Time{}, false
+	}
+	days := float64(remaining) / float64(perDay)
+	return now.Add(time.Duration(days * 24 * float64(time.Hour))), true
+}
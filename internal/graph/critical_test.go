@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestCriticalPath(t *testing.T) {
+	a := makeIssue("A", model.StatusOpen, []string{"B", "C"}, nil)
+	a.Estimate = "4h"
+	b := makeIssue("B", model.StatusInProgress, []string{"D"}, []string{"A"}) // unestimated: 8h
+	c := makeIssue("C", model.StatusOpen, []string{"D"}, []string{"A"})
+	c.Estimate = "1h"
+	d := makeIssue("D", model.StatusOpen, nil, []string{"B", "C", "F"})
+	d.Estimate, d.Spent = "3h", "1h"
+	e := makeIssue("E", model.StatusOpen, nil, nil)
+	e.Estimate = "1h"
+	f := makeIssue("F", model.StatusClosed, []string{"D"}, nil)
+	f.Estimate = "1w"
+
+	sched, err := Build([]*model.Issue{a, b, c, d, e, f}).CriticalPath(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chain []string
+	for _, it := range sched.Chain {
+		chain = append(chain, it.Issue.ID)
+	}
+	if got := strings.Join(chain, ","); got != "A,B,D" {
+		t.Errorf("chain = %s, want A,B,D", got)
+	}
+	if sched.Length != 14*time.Hour {
+		t.Errorf("Length = %v, want 14h", sched.Length)
+	}
+	if sched.Remaining != 16*time.Hour {
+		t.Errorf("Remaining = %v, want 16h", sched.Remaining)
+	}
+
+	slack := map[string]time.Duration{}
+	for _, it := range sched.Items {
+		slack[it.Issue.ID] = it.Slack
+	}
+	want := map[string]time.Duration{"A": 0, "B": 0, "D": 0, "C": 7 * time.Hour, "E": 13 * time.Hour}
+	for id, w := range want {
+		if slack[id] != w {
+			t.Errorf("slack[%s] = %v, want %v", id, slack[id], w)
+		}
+	}
+	if _, ok := slack["F"]; ok {
+		t.Error("closed issue F should not be scheduled")
+	}
+	if sched.Items[len(sched.Items)-1].Issue.ID != "E" {
+		t.Errorf("Items should be ranked by slack, last = %s", sched.Items[len(sched.Items)-1].Issue.ID)
+	}
+}
+
+func TestCriticalPathScope(t *testing.T) {
+	a := makeIssue("A", model.StatusOpen, []string{"B"}, nil)
+	b := makeIssue("B", model.StatusOpen, nil, []string{"A"})
+	sched, err := Build([]*model.Issue{a, b}).CriticalPath(map[string]bool{"B": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sched.Items) != 1 || sched.Length != model.EffortDay {
+		t.Errorf("scoped schedule = %d items, %v; want 1 item, 8h", len(sched.Items), sched.Length)
+	}
+}
+
+func TestCriticalPathCycle(t *testing.T) {
+	a := makeIssue("A", model.StatusOpen, []string{"B"}, []string{"B"})
+	b := makeIssue("B", model.StatusOpen, []string{"A"}, []string{"A"})
+	c := makeIssue("C", model.StatusOpen, nil, nil)
+	_, err := Build([]*model.Issue{a, b, c}).CriticalPath(nil)
+	if err == nil || !strings.Contains(err.Error(), "A, B") {
+		t.Errorf("err = %v, want cycle among A, B", err)
+	}
+}
+
+func TestThroughputAndProjection(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	recent := makeIssue("A", model.StatusClosed, nil, nil)
+	recent.Estimate = "2d"
+	recent.ClosedAt = now.Add(-48 * time.Hour).Format(time.RFC3339)
+	unestimated := makeIssue("B", model.StatusClosed, nil, nil)
+	unestimated.ClosedAt = now.Add(-24 * time.Hour).Format(time.RFC3339)
+	old := makeIssue("C", model.StatusClosed, nil, nil)
+	old.ClosedAt = now.AddDate(0, -2, 0).Format(time.RFC3339)
+
+	rate, closed := Throughput([]*model.Issue{recent, unestimated, old}, now, 4*24*time.Hour)
+	if closed != 2 || rate != 6*time.Hour {
+		t.Fatalf("Throughput = %v over %d issues, want 6h over 2", rate, closed)
+	}
+	finish, ok := ProjectFinish(now, 12*time.Hour, rate)
+	if !ok || !finish.Equal(now.Add(48*time.Hour)) {
+		t.Errorf("ProjectFinish = %v, %v; want %v", finish, ok, now.Add(48*time.Hour))
+	}
+	if _, ok := ProjectFinish(now, time.Hour, 0); ok {
+		t.Error("ProjectFinish with zero rate should report no projection")
+	}
+}
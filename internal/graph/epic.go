@@ -1,6 +1,9 @@
 package graph
 
 import (
+	"sort"
+	"time"
+
 	"github.com/RamXX/nd/internal/model"
 )
 
@@ -18,6 +21,12 @@ type EpicSummary struct {
 	InProgress int
 	Closed     int
 	Blocked    int
+
+	// Effort rollup across all descendants. Remaining counts only
+	// non-closed issues, as estimate minus spent (never negative).
+	Estimated time.Duration
+	Spent     time.Duration
+	Remaining time.Duration
 }
 
 // EpicTree builds a tree rooted at the given epic ID.
@@ -72,10 +81,30 @@ func (g *Graph) countTree(node *EpicNode, summary *EpicSummary) {
 		if g.hasOpenBlockers(child.Issue) && child.Issue.Status != model.StatusBlocked {
 			summary.Blocked++
 		}
+		est, spent := child.Issue.EstimateDuration(), child.Issue.SpentDuration()
+		summary.Estimated += est
+		summary.Spent += spent
+		if child.Issue.Status != model.StatusClosed && est > spent {
+			summary.Remaining += est - spent
+		}
 		g.countTree(child, summary)
 	}
 }
 
+// Children returns the direct children of the given issue (by Parent field), sorted by ID.
+func (g *Graph) Children(parentID string) []*model.Issue {
+	var children []*model.Issue
+	for _, issue := range g.nodes {
+		if issue.Parent == parentID {
+			children = append(children, issue)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].ID < children[j].ID
+	})
+	return children
+}
+
 // Epics returns all issues of type epic.
 func (g *Graph) Epics() []*model.Issue {
 	var epics []*model.Issue
@@ -86,3 +115,22 @@ func (g *Graph) Epics() []*model.Issue {
 	}
 	return epics
 }
+
+// Descendants returns the IDs of every issue below the epic in its tree,
+// not including the epic itself. Returns nil when the epic is unknown.
+func (g *Graph) Descendants(epicID string) map[string]bool {
+	tree := g.EpicTree(epicID)
+	if tree == nil {
+		return nil
+	}
+	ids := make(map[string]bool)
+	var walk func(n *EpicNode)
+	walk = func(n *EpicNode) {
+		for _, c := range n.Children {
+			ids[c.Issue.ID] = true
+			walk(c)
+		}
+	}
+	walk(tree)
+	return ids
+}
@@ -146,3 +146,44 @@ func TestStats(t *testing.T) {
 		t.Errorf("in_progress = %d, want 1", s.InProgress)
 	}
 }
+
+func TestChildren(t *testing.T) {
+	b := makeIssue("E.2", model.StatusOpen, nil, nil)
+	a := makeIssue("E.1", model.StatusClosed, nil, nil)
+	other := makeIssue("X", model.StatusOpen, nil, nil)
+	a.Parent, b.Parent = "E", "E"
+	g := Build([]*model.Issue{makeIssue("E", model.StatusOpen, nil, nil), b, a, other})
+
+	children := g.Children("E")
+	if len(children) != 2 || children[0].ID != "E.1" || children[1].ID != "E.2" {
+		t.Errorf("Children(E) = %v, want [E.1 E.2]", children)
+	}
+	if len(g.Children("X")) != 0 {
+		t.Error("X should have no children")
+	}
+}
+
+func TestEpicStatusEffortRollup(t *testing.T) {
+	epic := makeIssue("E", model.StatusOpen, nil, nil)
+	epic.Type = model.TypeEpic
+	a := makeIssue("A", model.StatusInProgress, nil, nil)
+	a.Parent, a.Estimate, a.Spent = "E", "4h", "1h30m"
+	b := makeIssue("B", model.StatusClosed, nil, nil)
+	b.Parent, b.Estimate, b.Spent = "E", "2h", "3h"
+	sub := makeIssue("S", model.StatusOpen, nil, nil)
+	sub.Parent = "E"
+	c := makeIssue("C", model.StatusOpen, nil, nil)
+	c.Parent, c.Estimate = "S", "1d"
+
+	summary := Build([]*model.Issue{epic, a, b, sub, c}).EpicStatus("E")
+	if summary.Estimated != 14*time.Hour {
+		t.Errorf("Estimated = %s, want 14h", summary.Estimated)
+	}
+	if summary.Spent != 4*time.Hour+30*time.Minute {
+		t.Errorf("Spent = %s, want 4h30m", summary.Spent)
+	}
+	// A has 2h30m left, B is closed, C has its full day left.
+	if summary.Remaining != 10*time.Hour+30*time.Minute {
+		t.Errorf("Remaining = %s, want 10h30m", summary.Remaining)
+	}
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// Scoring weights for Recommend. Priority dominates; the other factors
+// reorder issues within and across neighbouring priorities.
+const (
+	scorePerPriority  = 10.0 // per level above P4
+	scorePerAgeDay    = 0.5  // per day since creation
+	scoreAgeCap       = 10.0
+	scoreOverdue      = 30.0
+	scoreDueSoon      = 15.0
+	scorePerUnblocked = 4.0 // per open issue transitively waiting on this one
+	scoreUnblockCap   = 20.0
+	scoreMomentum     = 10.0 // times the fraction of the parent's children closed
+	scoreFollowsUp    = 12.0 // unblocked by the actor's last closed issue
+	scoreSameParent   = 8.0  // sibling of the actor's last closed issue
+)
+
+// ScoreFactor is one term of a recommendation score.
+type ScoreFactor struct {
+	Name   string  `json:"name"`
+	Points float64 `json:"points"`
+	Detail string  `json:"detail"`
+}
+
+// Recommendation is a scored candidate for the next piece of work.
+type Recommendation struct {
+	Issue   *model.Issue
+	Score   float64
+	Factors []ScoreFactor
+}
+
+// NextOptions carries the context a recommendation is scored in.
+type NextOptions struct {
+	Now        time.Time
+	LastClosed *model.Issue // the actor's most recently closed issue, if any
+}
+
+// Recommend scores candidates and returns them best first. The score adds
+// up priority, age, due date, how many open issues transitively wait on
+// the candidate, how far along its parent epic is, and continuity with
+// the actor's last closed issue: a candidate that issue unblocked, or else
+// a sibling under the same parent (the heuristics auto-follows uses).
+func (g *Graph) Recommend(candidates []*model.Issue, opts NextOptions) []Recommendation {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	recs := make([]Recommendation, 0, len(candidates))
+	for _, issue := range candidates {
+		rec := Recommendation{Issue: issue}
+		add := func(name string, points float64, detail string) {
+			if points == 0 {
+				return
+			}
+			rec.Factors = append(rec.Factors, ScoreFactor{Name: name, Points: points, Detail: detail})
+			rec.Score += points
+		}
+
+		add("priority", float64(4-issue.Priority)*scorePerPriority, "priority "+issue.Priority.Short())
+
+		if days := opts.Now.Sub(issue.CreatedAt).Hours() / 24; days >= 1 {
+			add("age", min(days*scorePerAgeDay, scoreAgeCap), fmt.Sprintf("open %dd", int(days)))
+		}
+
+		switch {
+		case issue.IsOverdue(opts.Now):
+			add("due", scoreOverdue, "overdue since "+issue.Due)
+		case issue.IsDueSoon(opts.Now):
+			add("due", scoreDueSoon, "due "+issue.Due)
+		}
+
+		if n := g.openDependents(issue.ID); n > 0 {
+			add("unblocks", min(float64(n)*scorePerUnblocked, scoreUnblockCap), fmt.Sprintf("%d issue(s) wait on it", n))
+		}
+
+		if issue.Parent != "" {
+			siblings := g.Children(issue.Parent)
+			closed := 0
+			for _, sib := range siblings {
+				if sib.Status == model.StatusClosed {
+					closed++
+				}
+			}
+			if len(siblings) > 1 && closed > 0 {
+				add("momentum", scoreMomentum*float64(closed)/float64(len(siblings)),
+					fmt.Sprintf("%s is %d/%d done", issue.Parent, closed, len(siblings)))
+			}
+		}
+
+		if last := opts.LastClosed; last != nil && last.ID != issue.ID {
+			switch {
+			case containsID(issue.WasBlockedBy, last.ID) || containsID(issue.BlockedBy, last.ID):
+				add("continuity", scoreFollowsUp, "unblocked by your last closed issue "+last.ID)
+			case issue.Parent != "" && issue.Parent == last.Parent:
+				add("continuity", scoreSameParent, "same parent as your last closed issue "+last.ID)
+			}
+		}
+		recs = append(recs, rec)
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].Issue.ID < recs[j].Issue.ID
+	})
+	return recs
+}
+
+// openDependents counts the open issues that transitively wait on id.
+func (g *Graph) openDependents(id string) int {
+	seen := map[string]bool{id: true}
+	queue := []string{id}
+	count := 0
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range g.forward[cur] {
+			if seen[next] {
+				continue
+			}
+			seen[next] = true
+			if issue, ok := g.nodes[next]; ok && issue.IsOpen() {
+				count++
+				queue = append(queue, next)
+			}
+		}
+	}
+	return count
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
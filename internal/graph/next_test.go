@@ -0,0 +1,78 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func factor(r Recommendation, name string) float64 {
+	for _, f := range r.Factors {
+		if f.Name == name {
+			return f.Points
+		}
+	}
+	return 0
+}
+
+func TestRecommendScoresFactors(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	gate := makeIssue("GATE", model.StatusOpen, []string{"D1"}, nil)
+	d1 := makeIssue("D1", model.StatusOpen, []string{"D2"}, []string{"GATE"})
+	d2 := makeIssue("D2", model.StatusOpen, nil, []string{"D1"})
+	urgent := makeIssue("URGENT", model.StatusOpen, nil, nil)
+	urgent.Due = "2026-04-28"
+	sib := makeIssue("SIB", model.StatusOpen, nil, nil)
+	sib.Parent = "EPIC"
+	done := makeIssue("DONE", model.StatusClosed, nil, nil)
+	done.Parent = "EPIC"
+	old := makeIssue("OLD", model.StatusOpen, nil, nil)
+	old.CreatedAt = now.AddDate(0, 0, -60)
+	for _, i := range []*model.Issue{gate, d1, d2, urgent, sib, done} {
+		i.CreatedAt = now
+	}
+
+	g := Build([]*model.Issue{gate, d1, d2, urgent, sib, done, old})
+	recs := g.Recommend([]*model.Issue{gate, urgent, sib, old}, NextOptions{Now: now, LastClosed: done})
+	byID := map[string]Recommendation{}
+	for _, r := range recs {
+		byID[r.Issue.ID] = r
+	}
+
+	if got := factor(byID["GATE"], "unblocks"); got != 2*scorePerUnblocked {
+		t.Errorf("GATE unblocks = %v, want %v", got, 2*scorePerUnblocked)
+	}
+	if got := factor(byID["URGENT"], "due"); got != scoreOverdue {
+		t.Errorf("URGENT due = %v, want %v", got, scoreOverdue)
+	}
+	if got := factor(byID["OLD"], "age"); got != scoreAgeCap {
+		t.Errorf("OLD age = %v, want cap %v", got, scoreAgeCap)
+	}
+	if got := factor(byID["SIB"], "continuity"); got != scoreSameParent {
+		t.Errorf("SIB continuity = %v, want %v", got, scoreSameParent)
+	}
+	if got := factor(byID["SIB"], "momentum"); got != scoreMomentum/2 {
+		t.Errorf("SIB momentum = %v, want %v", got, scoreMomentum/2)
+	}
+	if recs[0].Issue.ID != "URGENT" {
+		t.Errorf("top pick = %s, want URGENT", recs[0].Issue.ID)
+	}
+	for i := 1; i < len(recs); i++ {
+		if recs[i].Score > recs[i-1].Score {
+			t.Errorf("recommendations not sorted by score: %v before %v", recs[i-1].Score, recs[i].Score)
+		}
+	}
+}
+
+func TestRecommendPriorityDominates(t *testing.T) {
+	p0 := makeIssue("P0", model.StatusOpen, nil, nil)
+	p0.Priority = 0
+	p3 := makeIssue("P3", model.StatusOpen, nil, nil)
+	p3.Priority = 3
+	recs := Build([]*model.Issue{p0, p3}).Recommend([]*model.Issue{p3, p0}, NextOptions{})
+	if recs[0].Issue.ID != "P0" || recs[0].Score != 40 {
+		t.Errorf("top = %s (%.1f), want P0 (40)", recs[0].Issue.ID, recs[0].Score)
+	}
+}
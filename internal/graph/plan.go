@@ -0,0 +1,204 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// PlanItem is one issue placed in a lane and a wave of a work plan.
+type PlanItem struct {
+	Issue *model.Issue
+	Lane  int // 0-based
+	Wave  int // 0-based
+}
+
+// HeldItem is an open issue the plan cannot schedule, with the reason.
+type HeldItem struct {
+	Issue  *model.Issue
+	Reason string
+}
+
+// Plan splits open work into parallel lanes. Every issue in a wave can run
+// at the same time as the others in that wave; a wave only starts once the
+// earlier waves it depends on are done.
+type Plan struct {
+	Lanes [][]*PlanItem
+	Waves [][]*PlanItem
+	Held  []HeldItem
+}
+
+// Plan schedules the open, non-epic issues in scope (all issues when scope
+// is nil) into at most lanes parallel lanes. Issues enter a wave once all of
+// their blockers are closed or placed in an earlier wave; within a wave the
+// highest priorities go first, and each issue joins the free lane whose
+// earlier work shares its parent or labels, so related changes stay with
+// one agent. Deferred issues, issues waiting on open work outside the
+// scope, and everything behind them are reported as held.
+func (g *Graph) Plan(scope map[string]bool, lanes int) (*Plan, error) {
+	if lanes < 1 {
+		return nil, fmt.Errorf("lanes must be at least 1")
+	}
+
+	work := make(map[string]*model.Issue)
+	var ids []string
+	for id, issue := range g.nodes {
+		if !issue.IsOpen() || issue.Type == model.TypeEpic || (scope != nil && !scope[id]) {
+			continue
+		}
+		work[id] = issue
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Hold deferred issues and anything gated on open work the plan can't
+	// schedule, repeating until dependents of held issues are held too.
+	held := make(map[string]string)
+	for id, issue := range work {
+		if issue.Status == model.StatusDeferred {
+			held[id] = "deferred"
+			if issue.DeferUntil != "" {
+				held[id] = "deferred until " + issue.DeferUntil
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, id := range ids {
+			if _, ok := held[id]; ok {
+				continue
+			}
+			deps := append([]string(nil), g.reverse[id]...)
+			sort.Strings(deps)
+			for _, dep := range deps {
+				blocker, ok := g.nodes[dep]
+				if !ok || !blocker.IsOpen() {
+					continue
+				}
+				if _, inPlan := work[dep]; !inPlan {
+					held[id] = "waits on " + dep + " (outside the plan)"
+				} else if _, isHeld := held[dep]; isHeld {
+					held[id] = "waits on held " + dep
+				} else {
+					continue
+				}
+				changed = true
+				break
+			}
+		}
+	}
+
+	plan := &Plan{Lanes: make([][]*PlanItem, lanes)}
+	for id, reason := range held {
+		plan.Held = append(plan.Held, HeldItem{Issue: work[id], Reason: reason})
+	}
+	sort.Slice(plan.Held, func(i, j int) bool { return plan.Held[i].Issue.ID < plan.Held[j].Issue.ID })
+
+	remaining := make(map[string]bool)
+	for id := range work {
+		if _, ok := held[id]; !ok {
+			remaining[id] = true
+		}
+	}
+	groups := make([]map[string]bool, lanes) // parent and label keys seen per lane
+
+	for len(remaining) > 0 {
+		var avail []*model.Issue
+		for id := range remaining {
+			if !g.waitsOn(id, remaining) {
+				avail = append(avail, work[id])
+			}
+		}
+		if len(avail) == 0 {
+			var stuck []string
+			for id := range remaining {
+				stuck = append(stuck, id)
+			}
+			sort.Strings(stuck)
+			return nil, fmt.Errorf("dependency cycle among %s; run nd dep cycles", strings.Join(stuck, ", "))
+		}
+		sort.Slice(avail, func(i, j int) bool {
+			if avail[i].Priority != avail[j].Priority {
+				return avail[i].Priority < avail[j].Priority
+			}
+			return avail[i].ID < avail[j].ID
+		})
+		if len(avail) > lanes {
+			avail = avail[:lanes]
+		}
+
+		wave := len(plan.Waves)
+		free := make([]bool, lanes)
+		for i := range free {
+			free[i] = true
+		}
+		var items []*PlanItem
+		for _, issue := range avail {
+			lane := bestLane(issue, groups, free, plan.Lanes)
+			free[lane] = false
+			item := &PlanItem{Issue: issue, Lane: lane, Wave: wave}
+			plan.Lanes[lane] = append(plan.Lanes[lane], item)
+			items = append(items, item)
+			if groups[lane] == nil {
+				groups[lane] = make(map[string]bool)
+			}
+			for _, k := range affinityKeys(issue) {
+				groups[lane][k] = true
+			}
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Lane < items[j].Lane })
+		plan.Waves = append(plan.Waves, items)
+		for _, issue := range avail {
+			delete(remaining, issue.ID)
+		}
+	}
+	return plan, nil
+}
+
+// waitsOn reports whether any of the issue's blockers is still in pending.
+func (g *Graph) waitsOn(id string, pending map[string]bool) bool {
+	for _, dep := range g.reverse[id] {
+		if dep != id && pending[dep] {
+			return true
+		}
+	}
+	return false
+}
+
+// affinityKeys lists what ties an issue to related work: its parent and labels.
+func affinityKeys(issue *model.Issue) []string {
+	var keys []string
+	if issue.Parent != "" {
+		keys = append(keys, "parent:"+issue.Parent)
+	}
+	for _, l := range issue.Labels {
+		keys = append(keys, "label:"+l)
+	}
+	return keys
+}
+
+// bestLane picks the free lane with the most related earlier work (a shared
+// parent counts double), then the shortest lane, then the lowest index.
+func bestLane(issue *model.Issue, groups []map[string]bool, free []bool, lanes [][]*PlanItem) int {
+	best, bestScore := -1, -1
+	for i := range free {
+		if !free[i] {
+			continue
+		}
+		score := 0
+		for _, k := range affinityKeys(issue) {
+			if groups[i][k] {
+				score++
+				if strings.HasPrefix(k, "parent:") {
+					score++
+				}
+			}
+		}
+		if best < 0 || score > bestScore || (score == bestScore && len(lanes[i]) < len(lanes[best])) {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
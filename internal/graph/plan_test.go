@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func laneIDs(p *Plan) []string {
+	var out []string
+	for _, lane := range p.Lanes {
+		var ids []string
+		for _, it := range lane {
+			ids = append(ids, it.Issue.ID)
+		}
+		out = append(out, strings.Join(ids, ","))
+	}
+	return out
+}
+
+func TestPlanWavesRespectBlockers(t *testing.T) {
+	a := makeIssue("A", model.StatusOpen, []string{"C"}, nil)
+	b := makeIssue("B", model.StatusOpen, nil, nil)
+	c := makeIssue("C", model.StatusOpen, nil, []string{"A"})
+	d := makeIssue("D", model.StatusOpen, nil, nil)
+	d.Priority = 0
+
+	p, err := Build([]*model.Issue{a, b, c, d}).Plan(nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Waves) != 2 {
+		t.Fatalf("got %d waves, want 2", len(p.Waves))
+	}
+	// D (P0) and A (P2, lower ID than B) fill wave 1; B and C follow.
+	first := map[string]bool{}
+	for _, it := range p.Waves[0] {
+		first[it.Issue.ID] = true
+	}
+	if !first["D"] || !first["A"] {
+		t.Errorf("wave 1 = %v, want A and D", first)
+	}
+	for _, it := range p.Waves[1] {
+		if it.Issue.ID == "C" && it.Wave != 1 {
+			t.Errorf("C scheduled in wave %d, want after A", it.Wave)
+		}
+	}
+}
+
+func TestPlanKeepsRelatedWorkTogether(t *testing.T) {
+	a1 := makeIssue("A1", model.StatusOpen, nil, nil)
+	a1.Parent = "EA"
+	b1 := makeIssue("B1", model.StatusOpen, nil, nil)
+	b1.Labels = []string{"ui"}
+	a2 := makeIssue("A2", model.StatusOpen, nil, nil)
+	a2.Parent, a2.Priority = "EA", 3
+	b2 := makeIssue("B2", model.StatusOpen, nil, nil)
+	b2.Labels, b2.Priority = []string{"ui"}, 3
+	epic := makeIssue("EA", model.StatusOpen, nil, nil)
+	epic.Type = model.TypeEpic
+
+	p, err := Build([]*model.Issue{a1, b1, a2, b2, epic}).Plan(nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := laneIDs(p)
+	if got[0] != "A1,A2" || got[1] != "B1,B2" {
+		t.Errorf("lanes = %v, want [A1,A2 B1,B2]", got)
+	}
+}
+
+func TestPlanHeld(t *testing.T) {
+	a := makeIssue("A", model.StatusDeferred, []string{"B"}, nil)
+	b := makeIssue("B", model.StatusOpen, nil, []string{"A"})
+	c := makeIssue("C", model.StatusOpen, nil, []string{"X"})
+	x := makeIssue("X", model.StatusOpen, []string{"C"}, nil)
+	d := makeIssue("D", model.StatusOpen, nil, nil)
+
+	scope := map[string]bool{"A": true, "B": true, "C": true, "D": true}
+	p, err := Build([]*model.Issue{a, b, c, x, d}).Plan(scope, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reasons := map[string]string{}
+	for _, h := range p.Held {
+		reasons[h.Issue.ID] = h.Reason
+	}
+	if reasons["A"] != "deferred" || reasons["B"] != "waits on held A" || !strings.Contains(reasons["C"], "outside the plan") {
+		t.Errorf("held = %v", reasons)
+	}
+	if len(p.Waves) != 1 || len(p.Waves[0]) != 1 || p.Waves[0][0].Issue.ID != "D" {
+		t.Errorf("waves = %v, want just D", p.Waves)
+	}
+}
+
+func TestPlanCycle(t *testing.T) {
+	a := makeIssue("A", model.StatusOpen, []string{"B"}, []string{"B"})
+	b := makeIssue("B", model.StatusOpen, []string{"A"}, []string{"A"})
+	if _, err := Build([]*model.Issue{a, b}).Plan(nil, 2); err == nil {
+		t.Error("expected a cycle error")
+	}
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// BodySection is one ## section of an issue body.
+type BodySection struct {
+	Name    string
+	Content string
+}
+
+// Comment is one entry of the Comments section, written by nd comments add
+// as a "### <time> <author>" heading followed by the text.
+type Comment struct {
+	At     string // RFC3339; empty when the heading has no timestamp
+	Author string
+	Text   string
+}
+
+// HistoryEntry is one "- <time> <text>" line of the History section.
+type HistoryEntry struct {
+	At   string // RFC3339; empty when the line has no timestamp
+	Text string
+}
+
+// Sections splits a markdown body into its ## sections, in order. Text
+// before the first heading is dropped.
+func Sections(body string) []BodySection {
+	var out []BodySection
+	var cur *BodySection
+	var lines []string
+	flush := func() {
+		if cur != nil {
+			cur.Content = strings.TrimSpace(strings.Join(lines, "\n"))
+			out = append(out, *cur)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			flush()
+			cur = &BodySection{Name: strings.TrimSpace(line[3:])}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return out
+}
+
+// Comments parses the Comments section of a body, oldest first.
+func Comments(body string) []Comment {
+	var out []Comment
+	var cur *Comment
+	var lines []string
+	flush := func() {
+		if cur != nil {
+			cur.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+			out = append(out, *cur)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(Section(body, "Comments"), "\n") {
+		if strings.HasPrefix(line, "### ") {
+			flush()
+			at, author := splitTimestamp(strings.TrimSpace(line[4:]))
+			cur = &Comment{At: at, Author: author}
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return out
+}
+
+// History parses the History section of a body, oldest first.
+func History(body string) []HistoryEntry {
+	var out []HistoryEntry
+	for _, line := range strings.Split(Section(body, "History"), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") {
+			continue
+		}
+		at, text := splitTimestamp(strings.TrimSpace(line[2:]))
+		out = append(out, HistoryEntry{At: at, Text: text})
+	}
+	return out
+}
+
+// splitTimestamp splits a leading RFC3339 timestamp off s.
+func splitTimestamp(s string) (at, rest string) {
+	first, rest, _ := strings.Cut(s, " ")
+	if _, err := time.Parse(time.RFC3339, first); err != nil {
+		return "", s
+	}
+	return first, strings.TrimSpace(rest)
+}
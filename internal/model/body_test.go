@@ -0,0 +1,34 @@
+package model
+
+import "testing"
+
+func TestBodySectionsCommentsHistory(t *testing.T) {
+	body := "preamble\n## Description\nDo it.\n\n## History\n- 2026-01-01T00:00:00Z status: open -> closed\n- legacy entry\n\n## Comments\n\n### 2026-01-02T03:04:05Z alice\nfirst\nline two\n\n### bob\nundated\n"
+
+	sections := Sections(body)
+	if len(sections) != 3 || sections[0].Name != "Description" || sections[0].Content != "Do it." {
+		t.Fatalf("sections = %+v", sections)
+	}
+
+	history := History(body)
+	if len(history) != 2 {
+		t.Fatalf("history = %+v", history)
+	}
+	if history[0].At != "2026-01-01T00:00:00Z" || history[0].Text != "status: open -> closed" {
+		t.Errorf("history[0] = %+v", history[0])
+	}
+	if history[1].At != "" || history[1].Text != "legacy entry" {
+		t.Errorf("history[1] = %+v", history[1])
+	}
+
+	comments := Comments(body)
+	if len(comments) != 2 {
+		t.Fatalf("comments = %+v", comments)
+	}
+	if comments[0].At != "2026-01-02T03:04:05Z" || comments[0].Author != "alice" || comments[0].Text != "first\nline two" {
+		t.Errorf("comments[0] = %+v", comments[0])
+	}
+	if comments[1].At != "" || comments[1].Author != "bob" || comments[1].Text != "undated" {
+		t.Errorf("comments[1] = %+v", comments[1])
+	}
+}
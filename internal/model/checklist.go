@@ -0,0 +1,114 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ChecklistItem is a single markdown checkbox line (- [ ] or - [x]).
+type ChecklistItem struct {
+	Text    string
+	Checked bool
+	Line    int // zero-based line index within the section content
+}
+
+// Progress counts completed and total checklist items.
+type Progress struct {
+	Done  int
+	Total int
+}
+
+// Remaining returns the number of unchecked items.
+func (p Progress) Remaining() int { return p.Total - p.Done }
+
+// String renders progress as "done/total".
+func (p Progress) String() string {
+	return fmt.Sprintf("%d/%d", p.Done, p.Total)
+}
+
+// Section returns the content of the named ## section in a markdown body,
+// excluding the heading line. Returns "" when the section is absent.
+func Section(body, name string) string {
+	heading := "## " + name
+	lines := strings.Split(body, "\n")
+	start := -1
+	for i, line := range lines {
+		if start < 0 {
+			if strings.TrimRight(line, " ") == heading {
+				start = i + 1
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "## ") {
+			return strings.Join(lines[start:i], "\n")
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+	return strings.Join(lines[start:], "\n")
+}
+
+// ParseChecklist extracts checkbox items from markdown content.
+func ParseChecklist(content string) []ChecklistItem {
+	var items []ChecklistItem
+	for i, line := range strings.Split(content, "\n") {
+		text, checked, ok := parseCheckbox(line)
+		if !ok {
+			continue
+		}
+		items = append(items, ChecklistItem{Text: text, Checked: checked, Line: i})
+	}
+	return items
+}
+
+// SetCheckbox rewrites a checkbox line to the given state, preserving
+// indentation, bullet character, and text.
+func SetCheckbox(line string, checked bool) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(trimmed)]
+	mark := "[ ]"
+	if checked {
+		mark = "[x]"
+	}
+	return indent + trimmed[:2] + mark + trimmed[5:]
+}
+
+func parseCheckbox(line string) (text string, checked bool, ok bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if len(trimmed) < 5 {
+		return "", false, false
+	}
+	if trimmed[0] != '-' && trimmed[0] != '*' && trimmed[0] != '+' {
+		return "", false, false
+	}
+	if trimmed[1] != ' ' || trimmed[2] != '[' || trimmed[4] != ']' {
+		return "", false, false
+	}
+	switch trimmed[3] {
+	case ' ':
+		checked = false
+	case 'x', 'X':
+		checked = true
+	default:
+		return "", false, false
+	}
+	return strings.TrimSpace(trimmed[5:]), checked, true
+}
+
+// Checklist returns the checkbox items under the issue's Acceptance Criteria section.
+func (i *Issue) Checklist() []ChecklistItem {
+	return ParseChecklist(Section(i.Body, "Acceptance Criteria"))
+}
+
+// AcceptanceProgress counts checked and total acceptance criteria.
+func (i *Issue) AcceptanceProgress() Progress {
+	var p Progress
+	for _, item := range i.Checklist() {
+		p.Total++
+		if item.Checked {
+			p.Done++
+		}
+	}
+	return p
+}
@@ -0,0 +1,48 @@
+package model
+
+import "testing"
+
+func TestParseChecklist(t *testing.T) {
+	content := "\n- [ ] first\n- [x] second\n  * [X] nested\nplain line\n- [?] not a box\n"
+	items := ParseChecklist(content)
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d: %+v", len(items), items)
+	}
+	if items[0].Text != "first" || items[0].Checked {
+		t.Errorf("item 0 = %+v", items[0])
+	}
+	if !items[1].Checked || !items[2].Checked {
+		t.Errorf("items 1-2 should be checked: %+v", items)
+	}
+	if items[2].Line != 3 {
+		t.Errorf("nested item line = %d, want 3", items[2].Line)
+	}
+}
+
+func TestSetCheckbox(t *testing.T) {
+	tests := []struct {
+		line    string
+		checked bool
+		want    string
+	}{
+		{"- [ ] task", true, "- [x] task"},
+		{"- [x] task", false, "- [ ] task"},
+		{"  * [X] indented", false, "  * [ ] indented"},
+	}
+	for _, tt := range tests {
+		if got := SetCheckbox(tt.line, tt.checked); got != tt.want {
+			t.Errorf("SetCheckbox(%q, %v) = %q, want %q", tt.line, tt.checked, got, tt.want)
+		}
+	}
+}
+
+func TestAcceptanceProgress(t *testing.T) {
+	issue := &Issue{Body: "\n## Description\n- [x] not counted\n\n## Acceptance Criteria\n- [x] a\n- [ ] b\n- [ ] c\n\n## Design\n"}
+	p := issue.AcceptanceProgress()
+	if p.Done != 1 || p.Total != 3 || p.Remaining() != 2 {
+		t.Errorf("progress = %+v", p)
+	}
+	if p.String() != "1/3" {
+		t.Errorf("String() = %q", p.String())
+	}
+}
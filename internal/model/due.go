@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// DueSoonWindow is how far ahead an open issue's due date counts as "due soon".
+const DueSoonWindow = 72 * time.Hour
+
+// Deadline returns the instant an issue becomes overdue. A date-only due
+// value covers that whole day (UTC); an RFC3339 value is used as-is.
+// Returns false when the issue has no due date or it cannot be parsed.
+func (i *Issue) Deadline() (time.Time, bool) {
+	if i.Due == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.DateOnly, i.Due); err == nil {
+		return t.AddDate(0, 0, 1), true
+	}
+	if t, err := time.Parse(time.RFC3339, i.Due); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// IsOverdue reports whether an open issue is past its deadline.
+func (i *Issue) IsOverdue(now time.Time) bool {
+	d, ok := i.Deadline()
+	return ok && i.IsOpen() && !now.Before(d)
+}
+
+// IsDueSoon reports whether an open issue's deadline falls within
+// DueSoonWindow of now without having passed.
+func (i *Issue) IsDueSoon(now time.Time) bool {
+	d, ok := i.Deadline()
+	return ok && i.IsOpen() && now.Before(d) && d.Sub(now) <= DueSoonWindow
+}
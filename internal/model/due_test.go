@@ -0,0 +1,34 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDueState(t *testing.T) {
+	now := time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		due     string
+		status  Status
+		overdue bool
+		soon    bool
+	}{
+		{"", StatusOpen, false, false},
+		{"2026-03-03", StatusOpen, true, false},
+		{"2026-03-04", StatusOpen, false, true}, // due today: not late until the day ends
+		{"2026-03-06", StatusOpen, false, true},
+		{"2026-03-10", StatusOpen, false, false},
+		{"2026-03-04T12:00:00Z", StatusInProgress, true, false},
+		{"2026-03-01", StatusClosed, false, false},
+		{"soon", StatusOpen, false, false},
+	}
+	for _, tt := range tests {
+		i := &Issue{Due: tt.due, Status: tt.status}
+		if got := i.IsOverdue(now); got != tt.overdue {
+			t.Errorf("due %q (%s): IsOverdue = %v, want %v", tt.due, tt.status, got, tt.overdue)
+		}
+		if got := i.IsDueSoon(now); got != tt.soon {
+			t.Errorf("due %q (%s): IsDueSoon = %v, want %v", tt.due, tt.status, got, tt.soon)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Effort units: a day is a working day and a week is five of them, so
+// estimates read the way teams usually write them.
+const (
+	EffortDay  = 8 * time.Hour
+	EffortWeek = 5 * EffortDay
+)
+
+var effortPartRe = regexp.MustCompile(`(\d+(?:\.\d+)?)(w|d|h|m)`)
+
+// ParseEffort parses an effort such as "2h30m", "1.5d", or "1w2d".
+// Units are w (5d), d (8h), h, and m.
+func ParseEffort(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if s == "" {
+		return 0, fmt.Errorf("empty effort")
+	}
+	matches := effortPartRe.FindAllStringSubmatchIndex(s, -1)
+	var total time.Duration
+	pos := 0
+	for _, m := range matches {
+		if m[0] != pos {
+			break
+		}
+		n, _ := strconv.ParseFloat(s[m[2]:m[3]], 64)
+		unit := map[string]time.Duration{"w": EffortWeek, "d": EffortDay, "h": time.Hour, "m": time.Minute}[s[m[4]:m[5]]]
+		total += time.Duration(n * float64(unit))
+		pos = m[1]
+	}
+	if pos != len(s) || total <= 0 {
+		return 0, fmt.Errorf("invalid effort %q: use units w, d, h, m (e.g. 2h30m, 1.5d)", s)
+	}
+	return total.Round(time.Minute), nil
+}
+
+// FormatEffort renders a duration in hours and minutes ("10h30m", "45m").
+// Days are not used so that totals stay comparable at a glance.
+func FormatEffort(d time.Duration) string {
+	if d <= 0 {
+		return "0h"
+	}
+	d = d.Round(time.Minute)
+	h, m := int(d/time.Hour), int((d%time.Hour)/time.Minute)
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// EstimateDuration returns the parsed estimate, or 0 when unset or invalid.
+func (i *Issue) EstimateDuration() time.Duration {
+	d, _ := ParseEffort(i.Estimate)
+	return d
+}
+
+// SpentDuration returns the parsed logged total, or 0 when unset or invalid.
+func (i *Issue) SpentDuration() time.Duration {
+	d, _ := ParseEffort(i.Spent)
+	return d
+}
+
+// TimeEntry is one line written by nd log-time into the History section.
+type TimeEntry struct {
+	At       time.Time
+	Duration time.Duration
+	Author   string
+	Note     string
+}
+
+// timeEntryRe matches "- <RFC3339> time: <effort> by <author>[: <note>]".
+var timeEntryRe = regexp.MustCompile(`^- (\S+) time: (\S+) by (\S+?)(?:: (.*))?$`)
+
+// FormatTimeEntry renders the History line body for a time entry (without
+// the leading timestamp, which appendHistory adds).
+func FormatTimeEntry(d time.Duration, author, note string) string {
+	line := fmt.Sprintf("time: %s by %s", FormatEffort(d), author)
+	if note = strings.TrimSpace(note); note != "" {
+		line += ": " + strings.ReplaceAll(note, "\n", " ")
+	}
+	return line
+}
+
+// TimeLog returns the time entries recorded in the issue's History section.
+func (i *Issue) TimeLog() []TimeEntry {
+	var entries []TimeEntry
+	for _, line := range strings.Split(Section(i.Body, "History"), "\n") {
+		m := timeEntryRe.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, m[1])
+		if err != nil {
+			continue
+		}
+		d, err := ParseEffort(m[2])
+		if err != nil {
+			continue
+		}
+		entries = append(entries, TimeEntry{At: at, Duration: d, Author: m[3], Note: m[4]})
+	}
+	return entries
+}
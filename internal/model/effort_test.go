@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEffort(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"2h30m", 2*time.Hour + 30*time.Minute},
+		{"45m", 45 * time.Minute},
+		{"1.5d", 12 * time.Hour},
+		{"1w2d", 56 * time.Hour},
+		{"1H 15M", time.Hour + 15*time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseEffort(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseEffort(%q) = %s, %v; want %s", tt.input, got, err, tt.want)
+		}
+	}
+	for _, bad := range []string{"", "2", "2x", "h", "0h", "2h-1m", "1h30"} {
+		if _, err := ParseEffort(bad); err == nil {
+			t.Errorf("ParseEffort(%q) should fail", bad)
+		}
+	}
+	if got := FormatEffort(26*time.Hour + 5*time.Minute); got != "26h5m" {
+		t.Errorf("FormatEffort = %q", got)
+	}
+}
+
+func TestTimeLog(t *testing.T) {
+	i := &Issue{Body: "\n## Notes\n- 2026-03-01T10:00:00Z time: 9h by nobody\n\n## History\n" +
+		"- 2026-03-02T10:00:00Z status: open -> in_progress\n" +
+		"- 2026-03-02T12:00:00Z time: 2h30m by alice: fixed the parser\n" +
+		"- 2026-03-03T09:00:00Z time: 45m by bob\n\n## Links\n"}
+	log := i.TimeLog()
+	if len(log) != 2 {
+		t.Fatalf("TimeLog = %+v, want 2 entries from History only", log)
+	}
+	if log[0].Author != "alice" || log[0].Duration != 150*time.Minute || log[0].Note != "fixed the parser" {
+		t.Errorf("entry 0 = %+v", log[0])
+	}
+	if log[1].Author != "bob" || log[1].Note != "" {
+		t.Errorf("entry 1 = %+v", log[1])
+	}
+}
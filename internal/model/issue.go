@@ -135,7 +135,7 @@ var validTypes = map[IssueType]bool{
 func ParseIssueType(s string) (IssueType, error) {
 	t := IssueType(strings.ToLower(strings.TrimSpace(s)))
 	if !validTypes[t] {
-		return "", fmt.Errorf("invalid type %q: must be one of bug, feature, task, epic, chore, decision", s)
+		return "", fmt.Errorf("invalid type %q: must be one of %s", s, strings.Join(IssueTypeNames(), ", "))
 	}
 	return t, nil
 }
@@ -162,13 +162,20 @@ type Issue struct {
 	CreatedBy    string    `yaml:"created_by"`
 	UpdatedAt    time.Time `yaml:"updated_at"`
 	DeferUntil   string    `yaml:"defer_until,omitempty"`
+	Recur        string    `yaml:"recur,omitempty"`       // recurrence rule; closing spawns the next instance
+	Due          string    `yaml:"due,omitempty"`         // YYYY-MM-DD or RFC3339; see Deadline
+	Estimate     string    `yaml:"estimate,omitempty"`    // effort, e.g. "4h" or "1.5d"; see ParseEffort
+	Spent        string    `yaml:"spent,omitempty"`       // total time logged with nd log-time
+	LeaseUntil   string    `yaml:"lease_until,omitempty"` // RFC3339 expiry of an nd claim lease
+	PlanKey      string    `yaml:"plan_key,omitempty"`    // key of the nd apply plan item that created the issue
 	ClosedAt     string    `yaml:"closed_at,omitempty"`
 	CloseReason  string    `yaml:"close_reason,omitempty"`
 	ContentHash  string    `yaml:"content_hash"`
 
 	// Runtime fields -- not serialized to YAML frontmatter.
-	Body     string `yaml:"-"`
-	FilePath string `yaml:"-"`
+	Body       string   `yaml:"-"`
+	FilePath   string   `yaml:"-"`
+	ACProgress Progress `yaml:"-"` // acceptance-criteria checkbox counts, derived from Body
 }
 
 // Validate checks that required fields are populated and values are in range.
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// LeaseExpiry returns when the issue's claim lease runs out, or false when
+// it has no (valid) lease.
+func (i *Issue) LeaseExpiry() (time.Time, bool) {
+	if i.LeaseUntil == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, i.LeaseUntil)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// LeaseExpired reports whether the issue has a lease that ran out before now.
+func (i *Issue) LeaseExpired(now time.Time) bool {
+	t, ok := i.LeaseExpiry()
+	return ok && !now.Before(t)
+}
+
+// LeaseActive reports whether the issue has a lease still running at now.
+func (i *Issue) LeaseActive(now time.Time) bool {
+	t, ok := i.LeaseExpiry()
+	return ok && now.Before(t)
+}
@@ -0,0 +1,72 @@
+package model
+
+// IssueTypeNames returns the names of the issue types.
+func IssueTypeNames() []string {
+	return []string{"bug", "feature", "task", "epic", "chore", "decision"}
+}
+
+// IssueSchema returns a JSON Schema (draft 2020-12) for issue frontmatter.
+// custom lists the vault's custom statuses, accepted alongside built-ins.
+func IssueSchema(custom []Status) map[string]any {
+	statuses := BuiltinStatusNames()
+	for _, c := range custom {
+		statuses = append(statuses, string(c))
+	}
+	str := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "description": desc}
+	}
+	ids := func(desc string) map[string]any {
+		return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "uniqueItems": true, "description": desc}
+	}
+	timestamp := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "format": "date-time", "description": desc}
+	}
+	dateOrTime := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "anyOf": []any{
+			map[string]any{"format": "date"},
+			map[string]any{"format": "date-time"},
+		}, "description": desc}
+	}
+	effort := func(desc string) map[string]any {
+		return map[string]any{"type": "string", "pattern": `^(\d+(\.\d+)?[wdhm])+$`, "description": desc}
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         "https://github.com/RamXX/nd/schema/issue.json",
+		"title":       "nd issue frontmatter",
+		"description": "YAML frontmatter of an issue file in <vault>/issues/. The markdown body (Description, Acceptance Criteria, Design, Notes, History, Links, Comments) follows it.",
+		"type":        "object",
+		"required":    []string{"id", "title", "status", "priority", "type", "created_at", "created_by"},
+		"properties": map[string]any{
+			"id":             map[string]any{"type": "string", "pattern": `^[A-Za-z0-9]+-[a-z0-9]+(\.[0-9]+)*$`, "description": "PREFIX-hash, with .N suffixes for dot-notation children"},
+			"title":          map[string]any{"type": "string", "minLength": 1},
+			"status":         map[string]any{"type": "string", "enum": statuses},
+			"priority":       map[string]any{"type": "integer", "minimum": 0, "maximum": 4, "description": "0 critical, 1 high, 2 medium, 3 low, 4 backlog"},
+			"type":           map[string]any{"type": "string", "enum": IssueTypeNames()},
+			"assignee":       str("who owns the issue"),
+			"labels":         ids("labels"),
+			"parent":         str("parent issue (usually an epic) ID"),
+			"blocks":         ids("issues waiting on this one"),
+			"blocked_by":     ids("open issues this one waits on"),
+			"was_blocked_by": ids("former blockers, kept after they close"),
+			"related":        ids("bidirectional related links"),
+			"follows":        ids("predecessors in the execution path"),
+			"led_to":         ids("successors in the execution path"),
+			"created_at":     timestamp("creation time (RFC3339)"),
+			"created_by":     map[string]any{"type": "string", "minLength": 1},
+			"updated_at":     timestamp("last change (RFC3339)"),
+			"defer_until":    dateOrTime("hidden from ready work until then"),
+			"recur":          str("recurrence rule: 2w, monthly, or a cron expression; closing spawns the next instance"),
+			"due":            dateOrTime("deadline; a date covers the whole day (UTC)"),
+			"estimate":       effort("effort estimate; w is 5d and d is 8h"),
+			"spent":          effort("total time logged with nd log-time"),
+			"lease_until":    timestamp("expiry of an nd claim lease"),
+			"plan_key":       str("key of the nd apply plan item that created the issue; re-applying the plan updates it"),
+			"closed_at":      timestamp("when the issue was closed"),
+			"close_reason":   str("why the issue was closed"),
+			"content_hash":   map[string]any{"type": "string", "pattern": `^sha256:[0-9a-f]{64}$`, "description": "SHA-256 of the body; maintained by nd"},
+		},
+		"additionalProperties": true,
+	}
+}
@@ -0,0 +1,164 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A markdown plan is an outline. Each list item ("- ", "* ", or "+ ") is an
+// issue and nesting makes parents:
+//
+//	- Auth overhaul {key=auth type=epic priority=1 labels=auth,security}
+//	  Rework login and sessions.
+//	  - Session schema {key=schema estimate=4h}
+//	  - Login form {blocked_by=schema follows=schema}
+//
+// A trailing {...} holds name=value attributes named like the YAML fields,
+// with comma-separated lists. Lines indented under an item, up to its first
+// child, are its description. Headings and other unindented text are
+// ignored.
+
+// mdNode is an outline item while its children are collected.
+type mdNode struct {
+	item     Item
+	indent   int
+	desc     []string
+	children []*mdNode
+}
+
+func parseMarkdown(data []byte) (*Plan, error) {
+	root := &mdNode{indent: -1}
+	stack := []*mdNode{root}
+	var cur *mdNode  // item whose description is being read
+	inFence := false // inside a ``` block
+
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := strings.ReplaceAll(strings.TrimRight(raw, " \t\r"), "\t", "    ")
+		text := strings.TrimLeft(line, " ")
+		indent := len(line) - len(text)
+
+		fence := strings.HasPrefix(text, "```")
+		if title, ok := bulletText(text); ok && !inFence {
+			item, err := parseItemLine(title)
+			if err != nil {
+				return nil, fmt.Errorf("plan line %d: %w", n+1, err)
+			}
+			for stack[len(stack)-1].indent >= indent {
+				stack = stack[:len(stack)-1]
+			}
+			node := &mdNode{item: item, indent: indent}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+			cur = node
+			continue
+		}
+
+		switch {
+		case cur == nil:
+		case inFence || text == "" || indent > cur.indent:
+			cur.desc = append(cur.desc, line)
+		default:
+			cur = nil
+		}
+		if fence {
+			inFence = !inFence
+		}
+	}
+	return &Plan{Issues: mdItems(root.children)}, nil
+}
+
+// bulletText returns the text of a list item line, without a leading task
+// checkbox.
+func bulletText(text string) (string, bool) {
+	if len(text) < 2 || !strings.ContainsRune("-*+", rune(text[0])) || text[1] != ' ' {
+		return "", false
+	}
+	t := strings.TrimSpace(text[2:])
+	for _, box := range []string{"[ ] ", "[x] ", "[X] "} {
+		t = strings.TrimPrefix(t, box)
+	}
+	return t, t != ""
+}
+
+// parseItemLine splits "Title {name=value ...}" into an Item.
+func parseItemLine(text string) (Item, error) {
+	item := Item{Title: text}
+	open := strings.LastIndex(text, "{")
+	if open < 0 || !strings.HasSuffix(text, "}") {
+		return item, nil
+	}
+	item.Title = strings.TrimSpace(text[:open])
+	for _, attr := range strings.Fields(text[open+1 : len(text)-1]) {
+		name, value, ok := strings.Cut(attr, "=")
+		if !ok {
+			return item, fmt.Errorf("attribute %q: want name=value", attr)
+		}
+		switch strings.ReplaceAll(name, "-", "_") {
+		case "key":
+			item.Key = value
+		case "type":
+			item.Type = value
+		case "priority":
+			item.Priority = value
+		case "assignee":
+			item.Assignee = value
+		case "labels":
+			item.Labels = splitList(value)
+		case "parent":
+			item.Parent = value
+		case "blocked_by":
+			item.BlockedBy = splitList(value)
+		case "follows":
+			item.Follows = splitList(value)
+		case "estimate":
+			item.Estimate = value
+		case "due":
+			item.Due = value
+		default:
+			return item, fmt.Errorf("unknown attribute %q", name)
+		}
+	}
+	return item, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func mdItems(nodes []*mdNode) []Item {
+	var out []Item
+	for _, n := range nodes {
+		n.item.Description = dedent(n.desc)
+		n.item.Children = mdItems(n.children)
+		out = append(out, n.item)
+	}
+	return out
+}
+
+// dedent joins lines after removing their common indentation and trims
+// leading and trailing blank lines.
+func dedent(lines []string) string {
+	common := -1
+	for _, l := range lines {
+		if t := strings.TrimLeft(l, " "); t != "" {
+			if in := len(l) - len(t); common < 0 || in < common {
+				common = in
+			}
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= common && common > 0 {
+			l = l[common:]
+		}
+		out[i] = l
+	}
+	return strings.Trim(strings.Join(out, "\n"), "\n")
+}
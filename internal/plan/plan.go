@@ -0,0 +1,166 @@
+// Package plan parses the plan files read by nd apply: a tree of issues with
+// local symbolic keys, written as YAML or as a markdown outline.
+package plan
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by Parse.
+const (
+	FormatYAML     = "yaml"
+	FormatMarkdown = "md"
+)
+
+// Item is one issue of a plan. Parent, BlockedBy, and Follows name other
+// items by key, or existing issues by key or ID. Empty fields are left
+// unchanged when the item matches an existing issue.
+type Item struct {
+	Key         string   `yaml:"key"` // defaults to a slug of the title
+	Title       string   `yaml:"title"`
+	Type        string   `yaml:"type"`
+	Priority    string   `yaml:"priority"` // 0-4 or P0-P4
+	Assignee    string   `yaml:"assignee"`
+	Labels      []string `yaml:"labels"`
+	Description string   `yaml:"description"`
+	Parent      string   `yaml:"parent"`
+	BlockedBy   []string `yaml:"blocked_by"`
+	Follows     []string `yaml:"follows"`
+	Estimate    string   `yaml:"estimate"`
+	Due         string   `yaml:"due"`      // YYYY-MM-DD or RFC3339, never relative
+	Children    []Item   `yaml:"children"` // parent is this item
+}
+
+// Plan is a parsed plan file. Name scopes its keys: an issue records
+// "<name>/<key>" as its plan_key, so plans with the same keys do not match
+// each other's issues.
+type Plan struct {
+	Name   string `yaml:"plan"` // defaults to the file's base name
+	Issues []Item `yaml:"issues"`
+}
+
+// NameFor is the default plan name for a plan file: its base name without
+// the extension. It is empty for stdin ("-").
+func NameFor(file string) string {
+	if file == "" || file == "-" {
+		return ""
+	}
+	base := filepath.Base(file)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// QualifiedKey is the plan_key an item with key records under this plan.
+func (p *Plan) QualifiedKey(key string) string {
+	return p.Name + "/" + key
+}
+
+// FormatFor picks the format of a plan file from its name: md for .md and
+// .markdown, yaml otherwise.
+func FormatFor(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".md", ".markdown":
+		return FormatMarkdown
+	default:
+		return FormatYAML
+	}
+}
+
+// Parse reads a plan in the given format and validates it with Items.
+func Parse(data []byte, format string) (*Plan, error) {
+	var p *Plan
+	var err error
+	switch format {
+	case FormatYAML:
+		p, err = parseYAML(data)
+	case FormatMarkdown:
+		p, err = parseMarkdown(data)
+	default:
+		return nil, fmt.Errorf("unknown plan format %q: must be %s or %s", format, FormatYAML, FormatMarkdown)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.Items(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Items flattens the plan tree in file order, parents before children.
+// Nested items get their parent's key as Parent and have no Children. Items
+// without a key get one from their title. It fails on a missing title, a
+// duplicate key, or a nested item that also names a parent.
+func (p *Plan) Items() ([]Item, error) {
+	var out []Item
+	seen := make(map[string]bool)
+	var walk func(items []Item, parent string) error
+	walk = func(items []Item, parent string) error {
+		for _, it := range items {
+			it.Title = strings.TrimSpace(it.Title)
+			if it.Title == "" {
+				return fmt.Errorf("plan item %q: title is required", it.Key)
+			}
+			if it.Key == "" {
+				it.Key = Slug(it.Title)
+			}
+			if seen[it.Key] {
+				return fmt.Errorf("plan item %q: duplicate key", it.Key)
+			}
+			seen[it.Key] = true
+			if parent != "" {
+				if it.Parent != "" && it.Parent != parent {
+					return fmt.Errorf("plan item %q: nested under %q but parent is %q", it.Key, parent, it.Parent)
+				}
+				it.Parent = parent
+			}
+			children := it.Children
+			it.Children = nil
+			out = append(out, it)
+			if err := walk(children, it.Key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(p.Issues, ""); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Slug turns a title into a key: lowercase letters and digits, with other
+// runs of characters replaced by a single hyphen.
+func Slug(title string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return sb.String()
+}
+
+func parseYAML(data []byte) (*Plan, error) {
+	var p Plan
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse plan: %w", err)
+	}
+	return &p, nil
+}
@@ -0,0 +1,121 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseYAML(t *testing.T) {
+	src := `
+plan: q3
+issues:
+  - key: auth
+    title: Auth overhaul
+    type: epic
+    priority: 1
+    labels: [auth]
+    children:
+      - key: schema
+        title: Session schema
+      - title: Login form
+        blocked_by: [schema]
+        follows: [schema]
+  - title: Docs
+    parent: auth
+`
+	p, err := Parse([]byte(src), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	items, err := p.Items()
+	if err != nil {
+		t.Fatalf("Items: %v", err)
+	}
+	var got []string
+	for _, it := range items {
+		got = append(got, it.Key+"<"+it.Parent)
+	}
+	if want := "auth<,schema<auth,login-form<auth,docs<auth"; strings.Join(got, ",") != want {
+		t.Errorf("items = %s, want %s", strings.Join(got, ","), want)
+	}
+	if items[0].Priority != "1" || items[2].BlockedBy[0] != "schema" {
+		t.Errorf("fields not parsed: %+v %+v", items[0], items[2])
+	}
+	if p.Name != "q3" || p.QualifiedKey("auth") != "q3/auth" {
+		t.Errorf("name = %q, qualified key = %q", p.Name, p.QualifiedKey("auth"))
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	src := "# Plan\n\n" +
+		"- Auth overhaul {key=auth type=epic labels=auth,security}\n" +
+		"  Rework login.\n" +
+		"\n" +
+		"      indented code\n" +
+		"  - Session schema {key=schema estimate=4h}\n" +
+		"  - [ ] Login form {blocked-by=schema follows=schema}\n" +
+		"    Build the form.\n" +
+		"Trailing prose.\n" +
+		"* Docs\n"
+	p, err := Parse([]byte(src), FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	items, _ := p.Items()
+	if len(items) != 4 {
+		t.Fatalf("got %d items, want 4: %+v", len(items), items)
+	}
+	auth, login, docs := items[0], items[2], items[3]
+	if auth.Key != "auth" || auth.Type != "epic" || strings.Join(auth.Labels, ",") != "auth,security" {
+		t.Errorf("auth = %+v", auth)
+	}
+	if auth.Description != "Rework login.\n\n    indented code" {
+		t.Errorf("auth description = %q", auth.Description)
+	}
+	if login.Title != "Login form" || login.Parent != "auth" || login.BlockedBy[0] != "schema" || login.Description != "Build the form." {
+		t.Errorf("login = %+v", login)
+	}
+	if docs.Key != "docs" || docs.Parent != "" || docs.Description != "" {
+		t.Errorf("docs = %+v", docs)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct{ name, src, format string }{
+		{"unknown field", "issues:\n  - title: A\n    owner: bob\n", FormatYAML},
+		{"missing title", "issues:\n  - key: a\n", FormatYAML},
+		{"duplicate key", "- A\n- a\n", FormatMarkdown},
+		{"nested and parent", "- A\n  - B {parent=c}\n- C\n", FormatMarkdown},
+		{"unknown attribute", "- A {owner=bob}\n", FormatMarkdown},
+		{"bad format", "", "toml"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse([]byte(tt.src), tt.format); err == nil {
+			t.Errorf("%s: Parse should fail", tt.name)
+		}
+	}
+}
+
+func TestNameFor(t *testing.T) {
+	for in, want := range map[string]string{
+		"plans/auth.yaml": "auth",
+		"q3.plan.md":      "q3.plan",
+		"-":               "",
+	} {
+		if got := NameFor(in); got != want {
+			t.Errorf("NameFor(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSlug(t *testing.T) {
+	for in, want := range map[string]string{
+		"Login form":           "login-form",
+		"  OAuth 2.0 -- JWT! ": "oauth-2-0-jwt",
+		"Ünïcode title":        "ünïcode-title",
+	} {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RamXX/nd/internal/enforce"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/vlt"
+)
+
+const acceptanceHeading = "## Acceptance Criteria"
+
+// AcceptanceItems returns the checkbox items in an issue's Acceptance Criteria section.
+func (s *Store) AcceptanceItems(id string) ([]model.ChecklistItem, error) {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	return issue.Checklist(), nil
+}
+
+// SetAcceptanceItem checks or unchecks the nth (1-based) acceptance criterion.
+// Only the matching checkbox line is rewritten; the rest of the section is
+// left untouched. Setting an item to its current state is a no-op.
+func (s *Store) SetAcceptanceItem(id string, n int, checked bool) (model.ChecklistItem, error) {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	section := model.Section(issue.Body, "Acceptance Criteria")
+	items := model.ParseChecklist(section)
+	if len(items) == 0 {
+		return model.ChecklistItem{}, validationError(fmt.Errorf("issue %s has no acceptance criteria checkboxes", id))
+	}
+	if n < 1 || n > len(items) {
+		return model.ChecklistItem{}, validationError(fmt.Errorf("acceptance criterion %d out of range: %s has %d", n, id, len(items)))
+	}
+
+	item := items[n-1]
+	if item.Checked == checked {
+		return item, nil
+	}
+
+	lines := strings.Split(section, "\n")
+	lines[item.Line] = model.SetCheckbox(lines[item.Line], checked)
+	content := strings.Join(lines, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	if err := s.vault.Patch(id, vlt.PatchOptions{
+		Heading:    acceptanceHeading,
+		Content:    content,
+		Timestamps: false,
+	}); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	updated, err := s.ReadIssue(id)
+	if err != nil {
+		return model.ChecklistItem{}, err
+	}
+	hash := enforce.ComputeContentHash(updated.Body)
+	if err := s.vault.PropertySet(id, "content_hash", fmt.Sprintf("%q", hash)); err != nil {
+		return model.ChecklistItem{}, err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return model.ChecklistItem{}, err
+	}
+
+	action := "unchecked"
+	if checked {
+		action = "checked"
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("ac: %s #%d %s", action, n, item.Text))
+
+	item.Checked = checked
+	return item, nil
+}
+
+// acGate is a transition that requires all acceptance criteria to be checked.
+// An empty From matches any source status.
+type acGate struct {
+	From model.Status
+	To   model.Status
+}
+
+// ACGates parses the status_ac_gate config into gated transitions.
+// Format: "closed,review->qa" (a bare status gates every transition into it).
+func (s *Store) ACGates() []acGate {
+	return parseACGates(s.config.StatusACGate)
+}
+
+func parseACGates(raw string) []acGate {
+	var gates []acGate
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(strings.ToLower(entry))
+		if entry == "" {
+			continue
+		}
+		if from, to, ok := strings.Cut(entry, "->"); ok {
+			gates = append(gates, acGate{
+				From: model.Status(strings.TrimSpace(from)),
+				To:   model.Status(strings.TrimSpace(to)),
+			})
+			continue
+		}
+		gates = append(gates, acGate{To: model.Status(entry)})
+	}
+	return gates
+}
+
+// checkACGate refuses a gated transition while acceptance criteria remain
+// unchecked. With force, the transition proceeds and the returned note
+// describes the override for the history log.
+func (s *Store) checkACGate(issue *model.Issue, to model.Status, force bool) (string, error) {
+	gated := false
+	for _, g := range s.ACGates() {
+		if g.To == to && (g.From == "" || g.From == issue.Status) {
+			gated = true
+			break
+		}
+	}
+	if !gated {
+		return "", nil
+	}
+
+	var open []string
+	for i, item := range issue.Checklist() {
+		if !item.Checked {
+			open = append(open, fmt.Sprintf("#%d %s", i+1, item.Text))
+		}
+	}
+	if len(open) == 0 {
+		return "", nil
+	}
+	if force {
+		return fmt.Sprintf("ac-gate overridden: %s -> %s with %d unchecked acceptance criteria", issue.Status, to, len(open)), nil
+	}
+	return "", s.refuse(issue, to, fmt.Errorf("acceptance criteria incomplete for %s -> %s: %d unchecked (%s); check them with nd ac check or use --force",
+		issue.Status, to, len(open), strings.Join(open, "; ")))
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func createWithAC(t *testing.T, s *Store) string {
+	t.Helper()
+	issue, err := s.CreateIssue("AC issue", "", "task", 2, "", nil, "")
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	read, _ := s.ReadIssue(issue.ID)
+	body := strings.Replace(read.Body, "## Acceptance Criteria\n\n", "## Acceptance Criteria\n- [ ] login works\n- [ ] tokens refresh\n\n", 1)
+	if err := s.UpdateBody(issue.ID, body); err != nil {
+		t.Fatalf("UpdateBody: %v", err)
+	}
+	return issue.ID
+}
+
+func TestSetAcceptanceItem(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	id := createWithAC(t, s)
+
+	item, err := s.SetAcceptanceItem(id, 2, true)
+	if err != nil {
+		t.Fatalf("SetAcceptanceItem: %v", err)
+	}
+	if item.Text != "tokens refresh" || !item.Checked {
+		t.Errorf("item = %+v", item)
+	}
+
+	read, _ := s.ReadIssue(id)
+	if !strings.Contains(read.Body, "- [ ] login works\n- [x] tokens refresh\n") {
+		t.Errorf("checkbox not toggled surgically:\n%s", read.Body)
+	}
+	if read.ACProgress.Done != 1 || read.ACProgress.Total != 2 {
+		t.Errorf("ACProgress = %+v", read.ACProgress)
+	}
+	if !strings.Contains(read.Body, "ac: checked #2 tokens refresh") {
+		t.Errorf("history missing ac entry:\n%s", read.Body)
+	}
+
+	if _, err := s.SetAcceptanceItem(id, 3, true); err == nil {
+		t.Error("out-of-range criterion should fail")
+	}
+}
+
+func TestACGateBlocksClose(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.ac_gate", "closed"); err != nil {
+		t.Fatalf("set ac_gate: %v", err)
+	}
+	id := createWithAC(t, s)
+
+	if err := s.CloseIssue(id, "done"); err == nil {
+		t.Fatal("close should be refused while criteria are unchecked")
+	} else if !strings.Contains(err.Error(), "#1 login works") {
+		t.Errorf("error should name unchecked criteria: %v", err)
+	}
+
+	if err := s.CloseIssue(id, "done", TransitionOptions{Force: true}); err != nil {
+		t.Fatalf("forced close: %v", err)
+	}
+	read, _ := s.ReadIssue(id)
+	if !strings.Contains(read.Body, "ac-gate overridden: open -> closed with 2 unchecked") {
+		t.Errorf("forced close should be logged in history:\n%s", read.Body)
+	}
+}
+
+func TestACGateTransition(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.custom", "review,qa"); err != nil {
+		t.Fatalf("set custom: %v", err)
+	}
+	if err := s.SetConfigValue("status.ac_gate", "review->qa"); err != nil {
+		t.Fatalf("set ac_gate: %v", err)
+	}
+	if err := s.SetConfigValue("status.ac_gate", "review->bogus"); err == nil {
+		t.Error("unknown status in ac gate should be rejected")
+	}
+	id := createWithAC(t, s)
+
+	// Ungated transitions are unaffected.
+	if err := s.UpdateStatus(id, "review"); err != nil {
+		t.Fatalf("open -> review: %v", err)
+	}
+	if err := s.UpdateStatus(id, "qa"); err == nil {
+		t.Fatal("review -> qa should be gated")
+	}
+	_, _ = s.SetAcceptanceItem(id, 1, true)
+	_, _ = s.SetAcceptanceItem(id, 2, true)
+	if err := s.UpdateStatus(id, "qa"); err != nil {
+		t.Fatalf("review -> qa with all criteria checked: %v", err)
+	}
+}
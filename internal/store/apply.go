@@ -0,0 +1,450 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/idgen"
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/nd/internal/plan"
+)
+
+// Apply actions.
+const (
+	ApplyCreate    = "create"
+	ApplyUpdate    = "update"
+	ApplyUnchanged = "unchanged"
+	ApplyOrphaned  = "orphaned" // an open issue of the plan that no item matches
+)
+
+// ApplyOptions adjusts Apply.
+type ApplyOptions struct {
+	DryRun bool // work out the actions without writing
+}
+
+// ApplyAction is what Apply did, or would do, for one plan item.
+type ApplyAction struct {
+	Key     string
+	ID      string
+	Title   string
+	Action  string   // ApplyCreate, ApplyUpdate, ApplyUnchanged, or ApplyOrphaned
+	Changes []string // for ApplyUpdate: the fields and links changed
+}
+
+// applyItem is a plan item with its references resolved and its values
+// normalized.
+type applyItem struct {
+	plan.Item
+	id        string
+	planKey   string       // plan_key: "<plan name>/<key>"
+	existing  *model.Issue // nil when the item creates an issue
+	itype     model.IssueType
+	priority  model.Priority
+	parent    string   // issue ID
+	blockedBy []string // issue IDs
+	follows   []string // issue IDs
+}
+
+// Apply creates and updates issues to match a plan. Items match existing
+// issues by plan_key, "<plan name>/<key>", so applying the same plan again
+// changes nothing, and applying an edited plan updates the issues it
+// created; other plans' issues never match. Fields an item
+// leaves empty are not touched, and links are only ever added.
+//
+// The whole plan is checked before anything is written: values must parse,
+// references must name a plan item or an existing issue, and the new
+// blocked_by, follows, and parent links must not close a cycle.
+func (s *Store) Apply(p *plan.Plan, opts ApplyOptions) ([]ApplyAction, error) {
+	all, err := s.ListIssues(FilterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.resolvePlan(p, all)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlanCycles(items, all); err != nil {
+		return nil, err
+	}
+
+	actions := make([]ApplyAction, len(items))
+	for i, it := range items {
+		actions[i] = ApplyAction{Key: it.Key, ID: it.id, Title: it.Title, Action: ApplyCreate}
+		if it.existing != nil {
+			actions[i].Changes = planChanges(it)
+			actions[i].Action = ApplyUnchanged
+			if len(actions[i].Changes) > 0 {
+				actions[i].Action = ApplyUpdate
+			}
+		}
+	}
+	actions = append(actions, planOrphans(p, items, all)...)
+	if opts.DryRun {
+		return actions, nil
+	}
+
+	// Create every new issue first so links between them can be added.
+	for _, it := range items {
+		if it.existing == nil {
+			if err := s.createPlanIssue(it); err != nil {
+				return nil, fmt.Errorf("plan item %q: %w", it.Key, err)
+			}
+		}
+	}
+	for _, it := range items {
+		if err := s.updatePlanIssue(it); err != nil {
+			return nil, fmt.Errorf("plan item %q: %w", it.Key, err)
+		}
+	}
+	return actions, nil
+}
+
+// planOrphans reports the open issues whose plan_key is under this plan but
+// matches no item, such as one created from an item that was since retitled
+// without an explicit key. They are left as they are.
+func planOrphans(p *plan.Plan, items []*applyItem, all []*model.Issue) []ApplyAction {
+	matched := make(map[string]bool, len(items))
+	for _, it := range items {
+		matched[it.planKey] = true
+	}
+	prefix := p.QualifiedKey("")
+	var out []ApplyAction
+	for _, issue := range all {
+		if issue.Status == model.StatusClosed || matched[issue.PlanKey] || !strings.HasPrefix(issue.PlanKey, prefix) {
+			continue
+		}
+		out = append(out, ApplyAction{Key: strings.TrimPrefix(issue.PlanKey, prefix), ID: issue.ID, Title: issue.Title, Action: ApplyOrphaned})
+	}
+	return out
+}
+
+// resolvePlan assigns each plan item its issue ID, new or matched by
+// plan_key, and validates and resolves its values.
+func (s *Store) resolvePlan(p *plan.Plan, all []*model.Issue) ([]*applyItem, error) {
+	if p.Name == "" || strings.Contains(p.Name, "/") {
+		return nil, validationError(fmt.Errorf("plan name %q: must be non-empty and contain no /", p.Name))
+	}
+	planItems, err := p.Items()
+	if err != nil {
+		return nil, validationError(err)
+	}
+	byKey := make(map[string]*model.Issue)
+	for _, issue := range all {
+		if issue.PlanKey == "" {
+			continue
+		}
+		if other, ok := byKey[issue.PlanKey]; ok {
+			return nil, validationError(fmt.Errorf("plan key %q is on both %s and %s", issue.PlanKey, other.ID, issue.ID))
+		}
+		byKey[issue.PlanKey] = issue
+	}
+
+	items := make([]*applyItem, len(planItems))
+	keyID := make(map[string]string, len(planItems))
+	reserved := make(map[string]bool)
+	for i, pi := range planItems {
+		it := &applyItem{Item: pi, planKey: p.QualifiedKey(pi.Key)}
+		it.existing = byKey[it.planKey]
+		if it.existing != nil {
+			it.id = it.existing.ID
+		} else {
+			it.id, err = idgen.GenerateID(s.config.Prefix, pi.Title, func(id string) bool {
+				return reserved[id] || s.IssueExists(id)
+			})
+			if err != nil {
+				return nil, fmt.Errorf("generate ID: %w", err)
+			}
+			reserved[it.id] = true
+		}
+		keyID[pi.Key] = it.id
+		items[i] = it
+	}
+
+	resolve := func(ref string) (string, error) {
+		if id, ok := keyID[ref]; ok {
+			return id, nil
+		}
+		if issue, ok := byKey[p.QualifiedKey(ref)]; ok {
+			return issue.ID, nil
+		}
+		if issue, ok := byKey[ref]; ok { // another plan's "<name>/<key>"
+			return issue.ID, nil
+		}
+		return s.ResolveID(ref)
+	}
+	now := time.Now()
+	for _, it := range items {
+		if err := it.normalize(now); err != nil {
+			return nil, validationError(fmt.Errorf("plan item %q: %w", it.Key, err))
+		}
+		if it.Parent != "" {
+			if it.parent, err = resolve(it.Parent); err != nil {
+				return nil, fmt.Errorf("plan item %q: parent %q: %w", it.Key, it.Parent, err)
+			}
+		}
+		for _, ref := range it.BlockedBy {
+			id, err := resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("plan item %q: blocked_by %q: %w", it.Key, ref, err)
+			}
+			it.blockedBy = appendUnique(it.blockedBy, id)
+		}
+		for _, ref := range it.Follows {
+			id, err := resolve(ref)
+			if err != nil {
+				return nil, fmt.Errorf("plan item %q: follows %q: %w", it.Key, ref, err)
+			}
+			it.follows = appendUnique(it.follows, id)
+		}
+		if it.parent == it.id || contains(it.blockedBy, it.id) || contains(it.follows, it.id) {
+			return nil, validationError(fmt.Errorf("plan item %q: refers to itself", it.Key))
+		}
+	}
+	return items, nil
+}
+
+// normalize parses the item's type, priority, estimate, and due date. A new
+// issue defaults to a P2 task. Due dates must be absolute so that applying
+// the plan again leaves them alone.
+func (it *applyItem) normalize(now time.Time) error {
+	if it.existing != nil {
+		it.itype, it.priority = it.existing.Type, it.existing.Priority
+	} else {
+		it.itype, it.priority = model.TypeTask, model.PriorityMedium
+	}
+	var err error
+	if it.Type != "" {
+		if it.itype, err = model.ParseIssueType(it.Type); err != nil {
+			return err
+		}
+	}
+	if it.Priority != "" {
+		if it.priority, err = model.ParsePriority(it.Priority); err != nil {
+			return err
+		}
+	}
+	if it.Estimate != "" {
+		d, err := model.ParseEffort(it.Estimate)
+		if err != nil {
+			return err
+		}
+		it.Estimate = model.FormatEffort(d)
+	}
+	if it.Due != "" {
+		if dates.Relative(it.Due) {
+			return fmt.Errorf("due %q is relative and would move on every apply: use YYYY-MM-DD or RFC3339", it.Due)
+		}
+		if it.Due, err = dates.Resolve(it.Due, now); err != nil {
+			return fmt.Errorf("invalid due: %w", err)
+		}
+	}
+	for i, l := range it.Labels {
+		it.Labels[i] = strings.TrimSpace(l)
+	}
+	it.Description = strings.TrimSpace(it.Description)
+	return nil
+}
+
+// checkPlanCycles refuses a plan whose links, together with the existing
+// ones in all, would put any plan item on a blocked_by, follows, or parent
+// cycle.
+func checkPlanCycles(items []*applyItem, all []*model.Issue) error {
+	names := make(map[string]string, len(items)) // ID -> key, for messages
+	deps := make(map[string]*model.Issue)        // edges: blocks
+	path := make(map[string]*model.Issue)        // edges: led_to
+	parents := make(map[string]string)
+	for _, issue := range all {
+		deps[issue.ID] = &model.Issue{ID: issue.ID, Blocks: append([]string(nil), issue.Blocks...)}
+		path[issue.ID] = &model.Issue{ID: issue.ID, Blocks: append([]string(nil), issue.LedTo...)}
+		parents[issue.ID] = issue.Parent
+	}
+	link := func(edges map[string]*model.Issue, from, to string) {
+		if edges[from] == nil {
+			edges[from] = &model.Issue{ID: from}
+		}
+		if edges[to] == nil {
+			edges[to] = &model.Issue{ID: to}
+		}
+		edges[from].Blocks = appendUnique(edges[from].Blocks, to)
+	}
+	for _, it := range items {
+		names[it.id] = it.Key
+		if it.parent != "" {
+			parents[it.id] = it.parent
+		}
+		for _, dep := range it.blockedBy {
+			link(deps, dep, it.id)
+		}
+		for _, pred := range it.follows {
+			link(path, pred, it.id)
+		}
+	}
+
+	label := func(id string) string {
+		if key, ok := names[id]; ok {
+			return key
+		}
+		return id
+	}
+	for _, g := range []struct {
+		kind   string
+		issues map[string]*model.Issue
+	}{{"dependency", deps}, {"follows", path}} {
+		var list []*model.Issue
+		for _, issue := range g.issues {
+			list = append(list, issue)
+		}
+		for _, cycle := range graph.Build(list).DetectCycles() {
+			for _, id := range cycle {
+				if _, ok := names[id]; !ok {
+					continue
+				}
+				labels := make([]string, len(cycle))
+				for i, c := range cycle {
+					labels[i] = label(c)
+				}
+				return validationError(fmt.Errorf("plan creates a %s cycle: %s", g.kind, strings.Join(labels, " -> ")))
+			}
+		}
+	}
+
+	for _, it := range items {
+		seen := map[string]bool{it.id: true}
+		chain := []string{label(it.id)}
+		for p := parents[it.id]; p != ""; p = parents[p] {
+			chain = append(chain, label(p))
+			if seen[p] {
+				return validationError(fmt.Errorf("plan creates a parent cycle: %s", strings.Join(chain, " -> ")))
+			}
+			seen[p] = true
+		}
+	}
+	return nil
+}
+
+// planChanges lists what applying it would change on its existing issue.
+func planChanges(it *applyItem) []string {
+	issue := it.existing
+	var out []string
+	if it.Title != issue.Title {
+		out = append(out, "title")
+	}
+	if it.itype != issue.Type {
+		out = append(out, "type")
+	}
+	if it.priority != issue.Priority {
+		out = append(out, "priority")
+	}
+	if it.Assignee != "" && it.Assignee != issue.Assignee {
+		out = append(out, "assignee")
+	}
+	if it.Labels != nil && strings.Join(it.Labels, ",") != strings.Join(issue.Labels, ",") {
+		out = append(out, "labels")
+	}
+	if it.Description != "" && it.Description != strings.TrimSpace(model.Section(issue.Body, "Description")) {
+		out = append(out, "description")
+	}
+	if it.parent != "" && it.parent != issue.Parent {
+		out = append(out, "parent")
+	}
+	if it.Estimate != "" && it.Estimate != issue.Estimate {
+		out = append(out, "estimate")
+	}
+	if it.Due != "" && it.Due != issue.Due {
+		out = append(out, "due")
+	}
+	for _, dep := range it.blockedBy {
+		if !hasBlocker(issue, dep) {
+			out = append(out, "blocked_by +"+dep)
+		}
+	}
+	for _, pred := range it.follows {
+		if !contains(issue.Follows, pred) {
+			out = append(out, "follows +"+pred)
+		}
+	}
+	return out
+}
+
+// createPlanIssue writes a new plan item without its blocked_by and follows
+// links; updatePlanIssue adds those once every new issue exists.
+func (s *Store) createPlanIssue(it *applyItem) error {
+	tmpl, err := s.TemplateForType(it.itype)
+	if err != nil {
+		return err
+	}
+	_, err = s.CreateIssueWithID(it.id, it.Title, it.Description, string(it.itype), int(it.priority), it.Assignee, it.Labels, it.parent,
+		CreateOptions{Template: tmpl, Due: it.Due, Estimate: it.Estimate, PlanKey: it.planKey})
+	return err
+}
+
+// updatePlanIssue brings the issue of it in line with the plan item and
+// adds its missing links.
+func (s *Store) updatePlanIssue(it *applyItem) error {
+	if issue := it.existing; issue != nil {
+		for _, change := range planChanges(it) {
+			var err error
+			switch change {
+			case "title":
+				err = s.UpdateField(it.id, "title", fmt.Sprintf("%q", it.Title))
+			case "type":
+				err = s.UpdateField(it.id, "type", string(it.itype))
+			case "priority":
+				err = s.UpdateField(it.id, "priority", fmt.Sprintf("%d", it.priority))
+			case "assignee":
+				err = s.UpdateField(it.id, "assignee", it.Assignee)
+			case "labels":
+				if len(it.Labels) == 0 {
+					err = s.vault.PropertyRemove(it.id, "labels")
+				} else {
+					err = s.UpdateField(it.id, "labels", fmt.Sprintf("[%s]", strings.Join(it.Labels, ", ")))
+				}
+			case "description":
+				err = s.UpdateDescription(it.id, it.Description)
+			case "parent":
+				err = s.SetParent(it.id, it.parent)
+			case "estimate":
+				err = s.SetEstimate(it.id, it.Estimate)
+			case "due":
+				err = s.SetDue(it.id, it.Due)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	for _, dep := range it.blockedBy {
+		if it.existing != nil && hasBlocker(it.existing, dep) {
+			continue
+		}
+		if err := s.AddDependency(it.id, dep); err != nil {
+			return err
+		}
+	}
+	for _, pred := range it.follows {
+		if it.existing != nil && contains(it.existing.Follows, pred) {
+			continue
+		}
+		if err := s.AddFollows(it.id, pred); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasBlocker reports whether dep blocks issue or did until it was closed.
+// A closed blocker moves to was_blocked_by, and the plan link is still
+// satisfied.
+func hasBlocker(issue *model.Issue, dep string) bool {
+	return contains(issue.BlockedBy, dep) || contains(issue.WasBlockedBy, dep)
+}
+
+func appendUnique(list []string, s string) []string {
+	if contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
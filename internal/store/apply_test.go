@@ -0,0 +1,215 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/plan"
+)
+
+func mustPlan(t *testing.T, src string) *plan.Plan {
+	t.Helper()
+	p, err := plan.Parse([]byte(src), plan.FormatMarkdown)
+	if err != nil {
+		t.Fatalf("parse plan: %v", err)
+	}
+	p.Name = "test"
+	return p
+}
+
+func actionSummary(actions []ApplyAction) string {
+	var out []string
+	for _, a := range actions {
+		out = append(out, a.Key+":"+a.Action)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestApplyCreatesAndIsIdempotent(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	src := "- Auth {key=auth type=epic priority=1}\n" +
+		"  - Schema {key=schema estimate=4h}\n" +
+		"  - Login {key=login blocked_by=schema follows=schema labels=ui}\n"
+
+	dry, err := s.Apply(mustPlan(t, src), ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if got := actionSummary(dry); got != "auth:create,schema:create,login:create" {
+		t.Errorf("dry run actions = %s", got)
+	}
+	if all, _ := s.ListIssues(FilterOptions{}); len(all) != 0 {
+		t.Fatalf("dry run wrote %d issues", len(all))
+	}
+
+	actions, err := s.Apply(mustPlan(t, src), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	ids := map[string]string{}
+	for _, a := range actions {
+		ids[a.Key] = a.ID
+	}
+	login, err := s.ReadIssue(ids["login"])
+	if err != nil {
+		t.Fatalf("read login: %v", err)
+	}
+	if login.PlanKey != "test/login" || login.Parent != ids["auth"] || !contains(login.BlockedBy, ids["schema"]) || !contains(login.Follows, ids["schema"]) {
+		t.Errorf("login = %+v", login)
+	}
+	schema, _ := s.ReadIssue(ids["schema"])
+	if !contains(schema.Blocks, ids["login"]) || !contains(schema.LedTo, ids["login"]) || schema.Estimate != "4h" {
+		t.Errorf("schema = %+v", schema)
+	}
+
+	again, err := s.Apply(mustPlan(t, src), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("re-apply: %v", err)
+	}
+	if got := actionSummary(again); got != "auth:unchanged,schema:unchanged,login:unchanged" {
+		t.Errorf("re-apply actions = %s", got)
+	}
+
+	edited := strings.Replace(src, "priority=1", "priority=0", 1) + "- Docs {blocked_by=login}\n"
+	actions, err = s.Apply(mustPlan(t, edited), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("apply edited: %v", err)
+	}
+	if got := actionSummary(actions); got != "auth:update,schema:unchanged,login:unchanged,docs:create" {
+		t.Errorf("edited actions = %s", got)
+	}
+	if auth, _ := s.ReadIssue(ids["auth"]); auth.Priority != 0 {
+		t.Errorf("auth priority = %d, want 0", auth.Priority)
+	}
+	if all, _ := s.ListIssues(FilterOptions{}); len(all) != 4 {
+		t.Errorf("got %d issues, want 4", len(all))
+	}
+}
+
+func TestApplyScopesKeysToPlan(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	first := mustPlan(t, "- Setup\n")
+	first.Name = "first"
+	created, err := s.Apply(first, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply first: %v", err)
+	}
+	second := mustPlan(t, "- Setup\n- Deploy {blocked_by=first/setup}\n")
+	second.Name = "second"
+	actions, err := s.Apply(second, ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply second: %v", err)
+	}
+	if got := actionSummary(actions); got != "setup:create,deploy:create" {
+		t.Errorf("second plan actions = %s", got)
+	}
+	if deploy, _ := s.ReadIssue(actions[1].ID); !contains(deploy.BlockedBy, created[0].ID) {
+		t.Errorf("deploy blocked_by = %v, want %s", deploy.BlockedBy, created[0].ID)
+	}
+
+	first.Name = ""
+	if _, err := s.Apply(first, ApplyOptions{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("unnamed plan: err = %v, want %v", err, ErrValidation)
+	}
+}
+
+func TestApplyReportsOrphanedIssues(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	if _, err := s.Apply(mustPlan(t, "- Setup\n- Deploy {key=deploy}\n"), ApplyOptions{}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	// Retitling an item without a key makes a new key; the old issue is
+	// reported, not changed.
+	actions, err := s.Apply(mustPlan(t, "- Set up\n- Deploy now {key=deploy}\n"), ApplyOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if got := actionSummary(actions); got != "set-up:create,deploy:update,setup:orphaned" {
+		t.Errorf("actions = %s", got)
+	}
+}
+
+func TestApplyKeepsClosedBlockers(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	src := "- Schema {key=schema}\n- Login {key=login blocked_by=schema}\n"
+	actions, err := s.Apply(mustPlan(t, src), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := s.CloseIssue(actions[0].ID, "done"); err != nil {
+		t.Fatalf("close blocker: %v", err)
+	}
+	if _, err := s.ResolveDependentsOf(actions[0].ID); err != nil {
+		t.Fatalf("resolve dependents: %v", err)
+	}
+	login, _ := s.ReadIssue(actions[1].ID)
+	if !contains(login.WasBlockedBy, actions[0].ID) {
+		t.Fatalf("login was_blocked_by = %v, want %s", login.WasBlockedBy, actions[0].ID)
+	}
+
+	again, err := s.Apply(mustPlan(t, src), ApplyOptions{})
+	if err != nil {
+		t.Fatalf("re-apply: %v", err)
+	}
+	if got := actionSummary(again); got != "schema:unchanged,login:unchanged" {
+		t.Errorf("re-apply actions = %s", got)
+	}
+	if login, _ := s.ReadIssue(actions[1].ID); len(login.BlockedBy) != 0 {
+		t.Errorf("re-apply re-added blocker: blocked_by = %v", login.BlockedBy)
+	}
+}
+
+func TestApplyRefusesBeforeWriting(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+	if _, err := s.Apply(mustPlan(t, "- X {key=x}\n- Y {key=y blocked_by=x}\n"), ApplyOptions{}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	tests := []struct {
+		name, src string
+		kind      error
+	}{
+		{"plan cycle", "- A {key=a blocked_by=b}\n- B {key=b blocked_by=a}\n", ErrValidation},
+		{"cycle through existing", "- New\n- X {key=x blocked_by=y}\n", ErrValidation},
+		{"follows cycle", "- A {key=a follows=b}\n- B {key=b follows=a}\n", ErrValidation},
+		{"parent cycle", "- A {key=a parent=b}\n- B {key=b parent=a}\n", ErrValidation},
+		{"self reference", "- A {key=a blocked_by=a}\n", ErrValidation},
+		{"unknown reference", "- A {blocked_by=TST-zzzz}\n", ErrNotFound},
+		{"bad priority", "- A {priority=9}\n", ErrValidation},
+		{"bad estimate", "- A {estimate=soon}\n", ErrValidation},
+		{"relative due", "- A {due=+1w}\n", ErrValidation},
+	}
+	for _, tt := range tests {
+		if _, err := s.Apply(mustPlan(t, tt.src), ApplyOptions{}); !errors.Is(err, tt.kind) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.kind)
+		}
+	}
+	if all, _ := s.ListIssues(FilterOptions{}); len(all) != 2 {
+		t.Errorf("refused plans wrote issues: got %d, want 2", len(all))
+	}
+}
@@ -0,0 +1,677 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkflowSchema is the structured workflow: section of .nd.yaml. It replaces
+// the CSV-encoded status_* keys; a vault uses one form or the other.
+type WorkflowSchema struct {
+	FSM         bool                     `yaml:"fsm,omitempty"`
+	Statuses    []StatusDef              `yaml:"statuses,omitempty"`
+	Sequence    []string                 `yaml:"sequence,omitempty"`
+	Transitions map[string][]string      `yaml:"transitions,omitempty"`
+	ACGate      []string                 `yaml:"ac_gate,omitempty"`
+	Guards      map[string][]string      `yaml:"guards,omitempty"`
+	WIPLimits   map[string]int           `yaml:"wip_limits,omitempty"`
+	WIPPerUser  map[string]int           `yaml:"wip_per_assignee,omitempty"`
+	Types       map[string]WorkflowRules `yaml:"types,omitempty"`
+	Labels      map[string]WorkflowRules `yaml:"labels,omitempty"`
+}
+
+// StatusDef declares a status in the structured schema. Built-in statuses may
+// be listed to attach a description or color; any other name is a custom status.
+type StatusDef struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Color       string `yaml:"color,omitempty" json:"color,omitempty"`
+}
+
+// WorkflowRules is a per-type or per-label override in the structured schema.
+type WorkflowRules struct {
+	Sequence    []string            `yaml:"sequence,omitempty"`
+	Transitions map[string][]string `yaml:"transitions,omitempty"`
+}
+
+// ConfigProblem is a single validation failure in .nd.yaml.
+type ConfigProblem struct {
+	Line    int
+	Message string
+}
+
+func (p ConfigProblem) Error() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// StatusDefs returns the declared metadata for built-in and custom statuses,
+// built-ins first. Statuses without metadata have only Name set.
+func (s *Store) StatusDefs() []StatusDef {
+	meta := make(map[string]StatusDef)
+	if s.config.Workflow != nil {
+		for _, d := range s.config.Workflow.Statuses {
+			meta[d.Name] = d
+		}
+	}
+	var defs []StatusDef
+	for _, name := range model.BuiltinStatusNames() {
+		defs = append(defs, statusDef(meta, name))
+	}
+	for _, st := range s.CustomStatuses() {
+		defs = append(defs, statusDef(meta, string(st)))
+	}
+	return defs
+}
+
+func statusDef(meta map[string]StatusDef, name string) StatusDef {
+	if d, ok := meta[name]; ok {
+		return d
+	}
+	return StatusDef{Name: name}
+}
+
+// MigrateConfig converts the legacy status_* keys into the structured
+// workflow: schema and returns the new .nd.yaml content. With dryRun the
+// file is left untouched. Vaults already on the schema are an error.
+func (s *Store) MigrateConfig(dryRun bool) ([]byte, error) {
+	if s.config.Workflow != nil {
+		return nil, fmt.Errorf(".nd.yaml already uses the workflow: schema")
+	}
+	cfg := s.config
+	cfg.Workflow = buildWorkflowSchema(s.config, nil)
+	data, err := marshalConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return data, nil
+	}
+	s.config.Workflow = cfg.Workflow
+	if err := os.WriteFile(filepath.Join(s.dir, ".nd.yaml"), data, 0o644); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// marshalConfig serializes cfg, writing workflow rules in whichever form the
+// vault uses. In schema mode the legacy fields are rebuilt into the schema
+// and omitted from the file.
+func marshalConfig(cfg Config) ([]byte, error) {
+	if cfg.Workflow != nil {
+		cfg.Workflow = buildWorkflowSchema(cfg, cfg.Workflow)
+		cfg.StatusCustom = ""
+		cfg.StatusSequence = ""
+		cfg.StatusFSM = false
+		cfg.StatusExitRules = ""
+		cfg.StatusACGate = ""
+		cfg.StatusGuards = ""
+		cfg.StatusWIPLimits = ""
+		cfg.StatusWIPPerAssignee = ""
+		cfg.Workflows = nil
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	return data, nil
+}
+
+// buildWorkflowSchema converts the in-memory rules into the structured
+// schema. Status metadata is carried over from prev.
+func buildWorkflowSchema(cfg Config, prev *WorkflowSchema) *WorkflowSchema {
+	w := &WorkflowSchema{FSM: cfg.StatusFSM}
+	custom := parseCSVStatuses(cfg.StatusCustom)
+
+	meta := make(map[string]StatusDef)
+	if prev != nil {
+		for _, d := range prev.Statuses {
+			meta[d.Name] = d
+		}
+	}
+	for _, name := range model.BuiltinStatusNames() {
+		if d, ok := meta[name]; ok {
+			w.Statuses = append(w.Statuses, d)
+		}
+	}
+	for _, st := range custom {
+		w.Statuses = append(w.Statuses, statusDef(meta, string(st)))
+	}
+
+	w.Sequence = statusStrings(parseCSVStatuses(cfg.StatusSequence))
+	w.Transitions = exitRulesMap(parseExitRules(cfg.StatusExitRules))
+	for _, g := range parseACGates(cfg.StatusACGate) {
+		if g.From == "" {
+			w.ACGate = append(w.ACGate, string(g.To))
+		} else {
+			w.ACGate = append(w.ACGate, string(g.From)+"->"+string(g.To))
+		}
+	}
+	w.WIPLimits = wipLimitsMap(parseWIPLimits(cfg.StatusWIPLimits))
+	w.WIPPerUser = wipLimitsMap(parseWIPLimits(cfg.StatusWIPPerAssignee))
+	if guards := parseGuards(cfg.StatusGuards); len(guards) > 0 {
+		w.Guards = make(map[string][]string)
+		for to, names := range guards {
+			w.Guards[string(to)] = names
+		}
+	}
+
+	for key, wf := range cfg.Workflows {
+		rules := WorkflowRules{
+			Sequence:    statusStrings(parseCSVStatuses(wf.Sequence)),
+			Transitions: exitRulesMap(parseExitRules(wf.ExitRules)),
+		}
+		if label, ok := strings.CutPrefix(key, "label:"); ok {
+			if w.Labels == nil {
+				w.Labels = make(map[string]WorkflowRules)
+			}
+			w.Labels[label] = rules
+			continue
+		}
+		if w.Types == nil {
+			w.Types = make(map[string]WorkflowRules)
+		}
+		w.Types[key] = rules
+	}
+	return w
+}
+
+// applyWorkflowSchema loads the structured schema into the in-memory rule
+// fields that the FSM, gates, and guards read.
+func applyWorkflowSchema(cfg *Config) {
+	w := cfg.Workflow
+	var custom []string
+	for _, d := range w.Statuses {
+		if !model.IsBuiltinStatus(d.Name) {
+			custom = append(custom, d.Name)
+		}
+	}
+	cfg.StatusCustom = strings.Join(custom, ",")
+	cfg.StatusSequence = strings.Join(w.Sequence, ",")
+	cfg.StatusFSM = w.FSM
+	cfg.StatusExitRules = formatExitRules(w.Transitions)
+	cfg.StatusACGate = strings.Join(w.ACGate, ",")
+
+	var guards []string
+	for _, to := range sortedKeys(w.Guards) {
+		guards = append(guards, to+":"+strings.Join(w.Guards[to], ","))
+	}
+	cfg.StatusGuards = strings.Join(guards, ";")
+	cfg.StatusWIPLimits = formatWIPLimits(w.WIPLimits)
+	cfg.StatusWIPPerAssignee = formatWIPLimits(w.WIPPerUser)
+
+	cfg.Workflows = nil
+	add := func(key string, rules WorkflowRules) {
+		if cfg.Workflows == nil {
+			cfg.Workflows = make(map[string]WorkflowConfig)
+		}
+		cfg.Workflows[key] = WorkflowConfig{
+			Sequence:  strings.Join(rules.Sequence, ","),
+			ExitRules: formatExitRules(rules.Transitions),
+		}
+	}
+	for name, rules := range w.Types {
+		add(name, rules)
+	}
+	for name, rules := range w.Labels {
+		add("label:"+name, rules)
+	}
+}
+
+func statusStrings(statuses []model.Status) []string {
+	out := make([]string, len(statuses))
+	for i, st := range statuses {
+		out[i] = string(st)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func exitRulesMap(rules map[model.Status][]model.Status) map[string][]string {
+	if len(rules) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(rules))
+	for from, targets := range rules {
+		out[string(from)] = statusStrings(targets)
+	}
+	return out
+}
+
+func wipLimitsMap(limits map[model.Status]int) map[string]int {
+	if len(limits) == 0 {
+		return nil
+	}
+	out := make(map[string]int, len(limits))
+	for st, n := range limits {
+		out[string(st)] = n
+	}
+	return out
+}
+
+func formatExitRules(rules map[string][]string) string {
+	var parts []string
+	for _, from := range sortedKeys(rules) {
+		parts = append(parts, from+":"+strings.Join(rules[from], ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateConfig checks .nd.yaml in dir without opening the vault, so it
+// works on configs that Open refuses. Problems carry YAML line numbers.
+func ValidateConfig(dir string) ([]ConfigProblem, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ".nd.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("read .nd.yaml: %w", err)
+	}
+	return validateConfigData(data), nil
+}
+
+// configProblemsError folds validation problems into the error Open returns.
+func configProblemsError(problems []ConfigProblem) error {
+	msgs := make([]string, len(problems))
+	for i, p := range problems {
+		msgs[i] = p.Error()
+	}
+	return fmt.Errorf("invalid .nd.yaml (run nd config validate):\n  %s", strings.Join(msgs, "\n  "))
+}
+
+var (
+	yamlErrLineRe = regexp.MustCompile(`line (\d+)`)
+	statusColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+var legacyWorkflowKeys = []string{
+	"status_custom", "status_sequence", "status_fsm", "status_exit_rules",
+	"status_ac_gate", "status_guards", "status_wip_limits", "status_wip_per_assignee",
+	"workflows",
+}
+
+type configValidator struct {
+	problems []ConfigProblem
+	custom   []model.Status
+}
+
+func (v *configValidator) add(n *yaml.Node, format string, args ...any) {
+	v.problems = append(v.problems, ConfigProblem{Line: n.Line, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *configValidator) check(n *yaml.Node, err error) {
+	if err != nil {
+		v.add(n, "%v", err)
+	}
+}
+
+// pairs returns the key/value nodes of a mapping, or reports a problem.
+func (v *configValidator) pairs(n *yaml.Node, what string) [][2]*yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		v.add(n, "%s must be a mapping", what)
+		return nil
+	}
+	var out [][2]*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		out = append(out, [2]*yaml.Node{n.Content[i], n.Content[i+1]})
+	}
+	return out
+}
+
+func (v *configValidator) scalar(n *yaml.Node, what string) (string, bool) {
+	if n.Kind != yaml.ScalarNode {
+		v.add(n, "%s must be a scalar", what)
+		return "", false
+	}
+	return n.Value, true
+}
+
+func (v *configValidator) boolean(n *yaml.Node, what string) bool {
+	val, ok := v.scalar(n, what)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		v.add(n, "%s must be true or false, got %q", what, val)
+	}
+	return b
+}
+
+func (v *configValidator) status(n *yaml.Node, what string) {
+	if val, ok := v.scalar(n, what); ok {
+		if _, err := model.ParseStatusWithCustom(val, v.custom); err != nil {
+			v.add(n, "%s: %v", what, err)
+		}
+	}
+}
+
+// statusList validates a YAML list of statuses with no duplicates.
+func (v *configValidator) statusList(n *yaml.Node, what string) int {
+	if n.Kind != yaml.SequenceNode {
+		v.add(n, "%s must be a list of statuses", what)
+		return 0
+	}
+	seen := make(map[string]bool)
+	for _, item := range n.Content {
+		v.status(item, what)
+		if seen[item.Value] {
+			v.add(item, "duplicate status %q in %s", item.Value, what)
+		}
+		seen[item.Value] = true
+	}
+	return len(n.Content)
+}
+
+func (v *configValidator) transitions(n *yaml.Node, what string) {
+	for _, kv := range v.pairs(n, what) {
+		v.status(kv[0], what)
+		v.statusList(kv[1], what+"."+kv[0].Value)
+	}
+}
+
+func (v *configValidator) rules(n *yaml.Node, what string) int {
+	seqLen := 0
+	for _, kv := range v.pairs(n, what) {
+		switch kv[0].Value {
+		case "sequence":
+			seqLen = v.statusList(kv[1], what+".sequence")
+		case "transitions":
+			v.transitions(kv[1], what+".transitions")
+		default:
+			v.add(kv[0], "unknown key %q in %s: must be sequence or transitions", kv[0].Value, what)
+		}
+	}
+	return seqLen
+}
+
+// validateConfigData checks raw .nd.yaml content, covering both the legacy
+// status_* keys and the structured workflow: schema.
+func validateConfigData(data []byte) []ConfigProblem {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line := 0
+		if m := yamlErrLineRe.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		return []ConfigProblem{{Line: line, Message: err.Error()}}
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	v := &configValidator{}
+	fields := make(map[string]*yaml.Node)
+	keys := make(map[string]*yaml.Node)
+	for _, kv := range v.pairs(doc.Content[0], "config") {
+		keys[kv[0].Value] = kv[0]
+		fields[kv[0].Value] = kv[1]
+	}
+
+	for name, key := range keys {
+		switch name {
+		case "version", "prefix", "created_by":
+			v.scalar(fields[name], name)
+		case "track_issues", "guard_allow_vault_writes":
+			v.boolean(fields[name], name)
+		case "due_sla":
+			if val, ok := v.scalar(fields[name], name); ok {
+				v.check(fields[name], validateSLAValue(val))
+			}
+		case "prime":
+			v.validatePrime(fields[name])
+		case "workflow":
+		default:
+			if !contains(legacyWorkflowKeys, name) {
+				v.add(key, "unknown config key %q", name)
+			}
+		}
+	}
+
+	if wf, ok := fields["workflow"]; ok {
+		for _, legacy := range legacyWorkflowKeys {
+			if key, ok := keys[legacy]; ok {
+				v.add(key, "%s cannot be combined with workflow:; run nd config migrate or remove it", legacy)
+			}
+		}
+		v.validateWorkflow(wf)
+	} else {
+		v.validateLegacy(fields)
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].Line < v.problems[j].Line })
+	return v.problems
+}
+
+func (v *configValidator) validateLegacy(fields map[string]*yaml.Node) {
+	if n, ok := fields["status_custom"]; ok {
+		if val, ok := v.scalar(n, "status_custom"); ok {
+			v.check(n, validateCustomValue(val))
+			v.custom = parseCSVStatuses(val)
+		}
+	}
+	validators := []struct {
+		key string
+		fn  func(string, []model.Status) error
+	}{
+		{"status_sequence", validateSequenceValue},
+		{"status_exit_rules", validateExitRulesValue},
+		{"status_ac_gate", validateACGateValue},
+		{"status_guards", validateGuardsValue},
+		{"status_wip_limits", validateWIPValue},
+		{"status_wip_per_assignee", validateWIPValue},
+	}
+	for _, c := range validators {
+		if n, ok := fields[c.key]; ok {
+			if val, ok := v.scalar(n, c.key); ok {
+				v.check(n, c.fn(val, v.custom))
+			}
+		}
+	}
+	hasSeq := false
+	if n, ok := fields["status_sequence"]; ok {
+		hasSeq = strings.TrimSpace(n.Value) != ""
+	}
+
+	if n, ok := fields["workflows"]; ok {
+		for _, kv := range v.pairs(n, "workflows") {
+			v.check(kv[0], validateWorkflowKey(kv[0].Value))
+			for _, f := range v.pairs(kv[1], "workflows."+kv[0].Value) {
+				val, ok := v.scalar(f[1], f[0].Value)
+				if !ok {
+					continue
+				}
+				switch f[0].Value {
+				case "sequence":
+					v.check(f[1], validateSequenceValue(val, v.custom))
+					hasSeq = hasSeq || val != ""
+				case "exit_rules":
+					v.check(f[1], validateExitRulesValue(val, v.custom))
+				default:
+					v.add(f[0], "unknown workflow field %q: must be sequence or exit_rules", f[0].Value)
+				}
+			}
+		}
+	}
+
+	if n, ok := fields["status_fsm"]; ok {
+		if v.boolean(n, "status_fsm") && !hasSeq {
+			v.add(n, "status_fsm is enabled but no status_sequence is set")
+		}
+	}
+}
+
+// validatePrime checks the prime: mapping of named nd prime profiles.
+func (v *configValidator) validatePrime(n *yaml.Node) {
+	for _, profile := range v.pairs(n, "prime") {
+		name := profile[0].Value
+		if !validConfigKeyRe.MatchString(name) {
+			v.add(profile[0], "invalid prime profile name %q: must be lowercase alphanumeric/underscore", name)
+		}
+		what := "prime." + name
+		for _, kv := range v.pairs(profile[1], what) {
+			field := what + "." + kv[0].Value
+			switch kv[0].Value {
+			case "sections":
+				if kv[1].Kind != yaml.SequenceNode {
+					v.add(kv[1], "%s must be a list of sections", field)
+					continue
+				}
+				for _, sec := range kv[1].Content {
+					if val, ok := v.scalar(sec, field); ok && !contains(PrimeSections, val) {
+						v.add(sec, "unknown prime section %q: must be one of %s", val, strings.Join(PrimeSections, ", "))
+					}
+				}
+			case "mine":
+				v.boolean(kv[1], field)
+			case "limit", "closed_days", "stale_days":
+				if val, ok := v.scalar(kv[1], field); ok {
+					if n, err := strconv.Atoi(val); err != nil || n < 0 {
+						v.add(kv[1], "%s must be a non-negative number, got %q", field, val)
+					}
+				}
+			default:
+				v.add(kv[0], "unknown key %q in %s: must be sections, limit, mine, closed_days, or stale_days", kv[0].Value, what)
+			}
+		}
+	}
+}
+
+func (v *configValidator) validateWorkflow(n *yaml.Node) {
+	fields := make(map[string]*yaml.Node)
+	for _, kv := range v.pairs(n, "workflow") {
+		fields[kv[0].Value] = kv[1]
+		switch kv[0].Value {
+		case "fsm", "statuses", "sequence", "transitions", "ac_gate", "guards", "wip_limits", "wip_per_assignee", "types", "labels":
+		default:
+			v.add(kv[0], "unknown key %q in workflow", kv[0].Value)
+		}
+	}
+
+	// Statuses first: every other field refers to them.
+	if list, ok := fields["statuses"]; ok {
+		if list.Kind != yaml.SequenceNode {
+			v.add(list, "workflow.statuses must be a list")
+		} else {
+			seen := make(map[string]bool)
+			for _, item := range list.Content {
+				v.statusDef(item, seen)
+			}
+		}
+	}
+
+	seqLen := 0
+	if seq, ok := fields["sequence"]; ok {
+		seqLen = v.statusList(seq, "workflow.sequence")
+	}
+	if tr, ok := fields["transitions"]; ok {
+		v.transitions(tr, "workflow.transitions")
+	}
+	if gate, ok := fields["ac_gate"]; ok {
+		if gate.Kind != yaml.SequenceNode {
+			v.add(gate, "workflow.ac_gate must be a list")
+		} else {
+			for _, item := range gate.Content {
+				if val, ok := v.scalar(item, "workflow.ac_gate"); ok {
+					v.check(item, validateACGateValue(val, v.custom))
+				}
+			}
+		}
+	}
+	if guards, ok := fields["guards"]; ok {
+		for _, kv := range v.pairs(guards, "workflow.guards") {
+			v.status(kv[0], "workflow.guards")
+			if kv[1].Kind != yaml.SequenceNode {
+				v.add(kv[1], "workflow.guards.%s must be a list of guard names", kv[0].Value)
+				continue
+			}
+			for _, g := range kv[1].Content {
+				if _, ok := guardRegistry[g.Value]; !ok {
+					v.add(g, "unknown guard %q: must be one of %s", g.Value, strings.Join(GuardNames(), ", "))
+				}
+			}
+		}
+	}
+	for _, key := range []string{"wip_limits", "wip_per_assignee"} {
+		if limits, ok := fields[key]; ok {
+			for _, kv := range v.pairs(limits, "workflow."+key) {
+				if kv[1].Kind != yaml.ScalarNode {
+					v.add(kv[1], "workflow.%s.%s must be a positive integer", key, kv[0].Value)
+					continue
+				}
+				v.check(kv[0], validateWIPEntry(kv[0].Value, kv[1].Value, v.custom))
+			}
+		}
+	}
+	if types, ok := fields["types"]; ok {
+		for _, kv := range v.pairs(types, "workflow.types") {
+			if _, err := model.ParseIssueType(kv[0].Value); err != nil {
+				v.add(kv[0], "workflow.types: %v", err)
+			}
+			seqLen += v.rules(kv[1], "workflow.types."+kv[0].Value)
+		}
+	}
+	if labels, ok := fields["labels"]; ok {
+		for _, kv := range v.pairs(labels, "workflow.labels") {
+			seqLen += v.rules(kv[1], "workflow.labels."+kv[0].Value)
+		}
+	}
+	if fsm, ok := fields["fsm"]; ok {
+		if v.boolean(fsm, "workflow.fsm") && seqLen == 0 {
+			v.add(fsm, "workflow.fsm is enabled but no sequence is set")
+		}
+	}
+}
+
+func (v *configValidator) statusDef(n *yaml.Node, seen map[string]bool) {
+	var name *yaml.Node
+	for _, kv := range v.pairs(n, "workflow.statuses entry") {
+		switch kv[0].Value {
+		case "name":
+			name = kv[1]
+		case "description":
+			v.scalar(kv[1], "description")
+		case "color":
+			if val, ok := v.scalar(kv[1], "color"); ok && !statusColorRe.MatchString(val) {
+				v.add(kv[1], "color %q must be a hex color like #59c2ff", val)
+			}
+		default:
+			v.add(kv[0], "unknown key %q in status: must be name, description, or color", kv[0].Value)
+		}
+	}
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+	if name == nil {
+		v.add(n, "status entry is missing name")
+		return
+	}
+	if seen[name.Value] {
+		v.add(name, "duplicate status %q", name.Value)
+	}
+	seen[name.Value] = true
+	if !model.IsBuiltinStatus(name.Value) {
+		if !validConfigKeyRe.MatchString(name.Value) {
+			v.add(name, "invalid status name %q: must be lowercase alphanumeric/underscore", name.Value)
+			return
+		}
+		v.custom = append(v.custom, model.Status(name.Value))
+	}
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestMigrateConfigRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+	if err := s.SetConfigValue("status.guards", "closed:close_reason"); err != nil {
+		t.Fatal(err)
+	}
+	before := s.Config()
+
+	if _, err := s.MigrateConfig(false); err != nil {
+		t.Fatalf("MigrateConfig: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".nd.yaml"))
+	if strings.Contains(string(data), "status_") || !strings.Contains(string(data), "workflow:") {
+		t.Fatalf("migrated file should only use workflow:\n%s", data)
+	}
+	if _, err := s.MigrateConfig(false); err == nil {
+		t.Error("migrating twice should fail")
+	}
+	s.Close()
+
+	s2, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open migrated vault: %v", err)
+	}
+	defer s2.Close()
+	after := s2.Config()
+	if after.StatusSequence != before.StatusSequence || after.StatusFSM != before.StatusFSM || after.StatusGuards != before.StatusGuards {
+		t.Errorf("rules changed across migration:\nbefore %+v\nafter  %+v", before, after)
+	}
+	if strings.Join(statusStrings(s2.CustomStatuses()), ",") != strings.Join(statusStrings(s.CustomStatuses()), ",") {
+		t.Errorf("custom statuses changed: %v", s2.CustomStatuses())
+	}
+	if s2.WorkflowFor(&model.Issue{Type: "bug"}).Name != "bug" {
+		t.Error("per-type workflow lost in migration")
+	}
+}
+
+func TestSchemaModeKeepsMetadataOnSet(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Init(dir, "TST", "tester"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := `version: "1"
+prefix: TST
+created_by: tester
+workflow:
+  statuses:
+    - name: review
+      description: Waiting for a reviewer
+      color: "#59c2ff"
+  sequence: [open, in_progress, review, closed]
+  wip_limits:
+    in_progress: 3
+`
+	if err := os.WriteFile(filepath.Join(dir, ".nd.yaml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	if got := s.StatusSequence(); len(got) != 4 || got[2] != "review" {
+		t.Errorf("StatusSequence = %v", got)
+	}
+	if err := s.SetConfigValue("status.custom", "review,qa"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".nd.yaml"))
+	for _, want := range []string{"Waiting for a reviewer", "name: qa", "in_progress: 3"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("saved config missing %q:\n%s", want, data)
+		}
+	}
+	if strings.Contains(string(data), "status_custom") {
+		t.Errorf("schema-mode vault should not gain legacy keys:\n%s", data)
+	}
+	defs := s.StatusDefs()
+	if defs[len(defs)-2].Description != "Waiting for a reviewer" {
+		t.Errorf("StatusDefs = %+v", defs)
+	}
+}
+
+func TestValidateConfigReportsLines(t *testing.T) {
+	data := []byte(`version: "1"
+prefix: TST
+status_custom: review
+status_sequence: open,nowhere,closed
+status_exit_rules: blocked
+status_fsm: maybe
+colour: red
+`)
+	problems := validateConfigData(data)
+	want := map[int]string{
+		4: "nowhere",
+		5: "invalid exit rule",
+		6: "true or false",
+		7: "unknown config key",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems, want %d: %v", len(problems), len(want), problems)
+	}
+	for _, p := range problems {
+		if !strings.Contains(p.Message, want[p.Line]) {
+			t.Errorf("line %d: %q should mention %q", p.Line, p.Message, want[p.Line])
+		}
+	}
+}
+
+func TestOpenRejectsInvalidConfig(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Init(dir, "TST", "tester"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := "version: \"1\"\nprefix: TST\nworkflow:\n  sequence: [open, review]\n"
+	if err := os.WriteFile(filepath.Join(dir, ".nd.yaml"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(dir); err == nil || !strings.Contains(err.Error(), "line 4") {
+		t.Errorf("Open should reject the undefined status with its line, got %v", err)
+	}
+	problems, err := ValidateConfig(dir)
+	if err != nil || len(problems) != 1 {
+		t.Errorf("ValidateConfig = %v, %v", problems, err)
+	}
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestDeferResolvesRelativeUntil(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, _ := s.CreateIssue("Later", "", "task", 2, "", nil, "")
+	if err := s.DeferIssue(issue.ID, "+2w"); err != nil {
+		t.Fatalf("DeferIssue: %v", err)
+	}
+	got, _ := s.ReadIssue(issue.ID)
+	want, _ := dates.Resolve("+2w", time.Now())
+	if got.DeferUntil != want {
+		t.Errorf("DeferUntil = %q, want %q", got.DeferUntil, want)
+	}
+	if err := s.DeferIssue(issue.ID, "someday"); err == nil {
+		t.Error("unparseable --until should be rejected")
+	}
+}
+
+func TestWakeDueIssues(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	due, _ := s.CreateIssue("Due", "", "task", 2, "", nil, "")
+	later, _ := s.CreateIssue("Later", "", "task", 2, "", nil, "")
+	forever, _ := s.CreateIssue("Indefinite", "", "task", 2, "", nil, "")
+	if err := s.DeferIssue(due.ID, "2026-01-10"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeferIssue(later.ID, "2026-02-01"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeferIssue(forever.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	woken, failed, err := s.WakeDueIssues(now)
+	if err != nil {
+		t.Fatalf("WakeDueIssues: %v", err)
+	}
+	if len(woken) != 1 || woken[0] != due.ID || len(failed) != 0 {
+		t.Fatalf("woken = %v, failed = %v; want only %s", woken, failed, due.ID)
+	}
+	got, _ := s.ReadIssue(due.ID)
+	if got.Status != model.StatusOpen || got.DeferUntil != "" {
+		t.Errorf("woken issue: status %s, defer_until %q", got.Status, got.DeferUntil)
+	}
+	for _, id := range []string{later.ID, forever.ID} {
+		if got, _ := s.ReadIssue(id); got.Status != model.StatusDeferred {
+			t.Errorf("%s should still be deferred, got %s", id, got.Status)
+		}
+	}
+}
+
+func TestFilterDeferWindow(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	soon, _ := s.CreateIssue("Soon", "", "task", 2, "", nil, "")
+	far, _ := s.CreateIssue("Far", "", "task", 2, "", nil, "")
+	open, _ := s.CreateIssue("Open", "", "task", 2, "", nil, "")
+	_ = s.DeferIssue(soon.ID, "2026-03-03")
+	_ = s.DeferIssue(far.ID, "2026-06-01")
+
+	got, err := s.ListIssues(FilterOptions{
+		DeferAfter:  time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		DeferBefore: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("ListIssues: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != soon.ID {
+		ids := make([]string, len(got))
+		for i, g := range got {
+			ids[i] = g.ID
+		}
+		t.Errorf("defer window returned %v, want only %s (not %s or %s)", ids, soon.ID, far.ID, open.ID)
+	}
+}
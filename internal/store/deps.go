@@ -9,7 +9,7 @@ import (
 // Updates both sides: adds depID to issue's blocked_by, and issue to depID's blocks.
 func (s *Store) AddDependency(issueID, depID string) error {
 	if issueID == depID {
-		return fmt.Errorf("an issue cannot depend on itself")
+		return validationError(fmt.Errorf("an issue cannot depend on itself"))
 	}
 
 	// Read both to validate they exist.
@@ -113,7 +113,7 @@ func contains(ss []string, s string) bool {
 // AddRelated adds a bidirectional related link between two issues.
 func (s *Store) AddRelated(issueID, relatedID string) error {
 	if issueID == relatedID {
-		return fmt.Errorf("an issue cannot relate to itself")
+		return validationError(fmt.Errorf("an issue cannot relate to itself"))
 	}
 
 	issue, err := s.ReadIssue(issueID)
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/model"
+)
+
+// SLA parses due_sla into priority -> offset (e.g. "1d", "12h").
+// Format: "P0:1d,P1:3d,P2:2w"
+func (s *Store) SLA() map[model.Priority]string {
+	return parseSLA(s.config.DueSLA)
+}
+
+func parseSLA(raw string) map[model.Priority]string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	result := make(map[model.Priority]string)
+	for _, entry := range strings.Split(raw, ",") {
+		name, offset, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		p, err := model.ParsePriority(name)
+		if err != nil {
+			continue
+		}
+		result[p] = strings.TrimSpace(offset)
+	}
+	return result
+}
+
+// validateSLAValue checks a due.sla value: priority:offset pairs where the
+// offset is a positive duration such as 12h, 1d, 2w, or 1m.
+func validateSLAValue(value string) error {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, offset, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("invalid SLA %q: expected format priority:offset (e.g. P0:1d)", entry)
+		}
+		if _, err := model.ParsePriority(name); err != nil {
+			return fmt.Errorf("invalid SLA %q: %w", entry, err)
+		}
+		offset = strings.TrimSpace(offset)
+		if strings.HasPrefix(offset, "-") || strings.HasPrefix(offset, "+") {
+			return fmt.Errorf("invalid SLA %q: offset must be a plain duration like 1d or 12h", entry)
+		}
+		if _, err := dates.Parse("+"+offset, time.Now()); err != nil {
+			return fmt.Errorf("invalid SLA %q: offset must be a duration like 12h, 1d, 2w, or 1m", entry)
+		}
+	}
+	return nil
+}
+
+// slaDue returns the default due date for a new issue of the given
+// priority, or "" when no SLA applies.
+func (s *Store) slaDue(p model.Priority, now time.Time) string {
+	offset, ok := s.SLA()[p]
+	if !ok {
+		return ""
+	}
+	due, err := dates.Resolve("+"+offset, now)
+	if err != nil {
+		return ""
+	}
+	return due
+}
+
+// SetDue sets an issue's due date. The value accepts everything
+// dates.Parse does (YYYY-MM-DD, RFC3339, today, tomorrow, +3d, ...);
+// an empty value clears it.
+func (s *Store) SetDue(id, value string) error {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	old := issue.Due
+	if old == "" {
+		old = "(none)"
+	}
+
+	if strings.TrimSpace(value) == "" {
+		if issue.Due == "" {
+			return nil
+		}
+		if err := s.vault.PropertyRemove(id, "due"); err != nil {
+			return err
+		}
+		if err := s.touchUpdatedAt(id); err != nil {
+			return err
+		}
+		_ = s.appendHistory(id, fmt.Sprintf("due: %s -> (none)", old))
+		return nil
+	}
+
+	due, err := dates.Resolve(value, time.Now())
+	if err != nil {
+		return validationError(fmt.Errorf("invalid --due: %w", err))
+	}
+	if err := s.vault.PropertySet(id, "due", due); err != nil {
+		return err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return err
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("due: %s -> %s", old, due))
+	return nil
+}
+
+// OverdueIssues returns open issues past their deadline and, when soon is
+// true, those due within model.DueSoonWindow, earliest deadline first.
+func (s *Store) OverdueIssues(now time.Time, soon bool) ([]*model.Issue, error) {
+	all, err := s.ListIssues(FilterOptions{Status: "!closed"})
+	if err != nil {
+		return nil, err
+	}
+	var out []*model.Issue
+	for _, issue := range all {
+		if issue.IsOverdue(now) || (soon && issue.IsDueSoon(now)) {
+			out = append(out, issue)
+		}
+	}
+	SortIssues(out, "due", false)
+	return out, nil
+}
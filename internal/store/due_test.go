@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+)
+
+func TestSLADefaultsDueDate(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("due.sla", "P0:1d,p1:2w"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	if err := s.SetConfigValue("due.sla", "P0:soon"); err == nil {
+		t.Error("non-duration SLA should be rejected")
+	}
+	if err := s.SetConfigValue("due.sla", "P9:1d"); err == nil {
+		t.Error("unknown priority should be rejected")
+	}
+
+	p0, _ := s.CreateIssue("Outage", "", "bug", 0, "", nil, "")
+	p2, _ := s.CreateIssue("Polish", "", "task", 2, "", nil, "")
+	explicit, _ := s.CreateIssue("Launch", "", "task", 0, "", nil, "", CreateOptions{Due: "2027-01-01"})
+
+	want, _ := dates.Resolve("+1d", time.Now())
+	if p0.Due != want {
+		t.Errorf("P0 due = %q, want %q", p0.Due, want)
+	}
+	if p2.Due != "" {
+		t.Errorf("P2 has no SLA, got due %q", p2.Due)
+	}
+	if explicit.Due != "2027-01-01" {
+		t.Errorf("explicit due should win over the SLA, got %q", explicit.Due)
+	}
+	if got := mustRead(t, s, p0.ID).Due; got != want {
+		t.Errorf("due not persisted: %q", got)
+	}
+}
+
+func TestSLACountsFromDeferUntil(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("due.sla", "P2:1d"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	until, _ := dates.Resolve("+1w", time.Now())
+	later, _ := s.CreateIssue("Later", "", "task", 2, "", nil, "", CreateOptions{DeferUntil: until})
+	want, _ := dates.Resolve("+8d", time.Now())
+	if later.Due != want {
+		t.Errorf("deferred issue due = %q, want %q (a day after %s)", later.Due, want, until)
+	}
+}
+
+func TestOverdueIssuesAndDueSort(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	late, _ := s.CreateIssue("Late", "", "task", 2, "", nil, "")
+	soon, _ := s.CreateIssue("Soon", "", "task", 2, "", nil, "")
+	later, _ := s.CreateIssue("Later", "", "task", 2, "", nil, "")
+	none, _ := s.CreateIssue("Whenever", "", "task", 2, "", nil, "")
+	done, _ := s.CreateIssue("Done", "", "task", 2, "", nil, "")
+	for id, due := range map[string]string{late.ID: "2026-03-01", soon.ID: "2026-03-05", later.ID: "2026-04-01", done.ID: "2026-02-01"} {
+		if err := s.SetDue(id, due); err != nil {
+			t.Fatalf("SetDue: %v", err)
+		}
+	}
+	_ = s.CloseIssue(done.ID, "")
+	if err := s.SetDue(none.ID, "someday"); !errors.Is(err, ErrValidation) {
+		t.Errorf("unparseable due: err = %v, want %v", err, ErrValidation)
+	}
+
+	now := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	overdue, _ := s.OverdueIssues(now, false)
+	if len(overdue) != 1 || overdue[0].ID != late.ID {
+		t.Errorf("OverdueIssues = %v, want only %s", overdue, late.ID)
+	}
+	withSoon, _ := s.OverdueIssues(now, true)
+	if len(withSoon) != 2 || withSoon[1].ID != soon.ID {
+		t.Errorf("OverdueIssues(soon) = %v, want %s then %s", withSoon, late.ID, soon.ID)
+	}
+
+	all, _ := s.ListIssues(FilterOptions{Status: "!closed", Sort: "due"})
+	var order []string
+	for _, i := range all {
+		order = append(order, i.ID)
+	}
+	want := []string{late.ID, soon.ID, later.ID, none.ID}
+	for i, id := range want {
+		if i >= len(order) || order[i] != id {
+			t.Fatalf("due sort = %v, want %v", order, want)
+		}
+	}
+}
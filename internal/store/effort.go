@@ -0,0 +1,169 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// SetEstimate sets an issue's effort estimate (see model.ParseEffort);
+// an empty value clears it.
+func (s *Store) SetEstimate(id, value string) error {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	old := issue.Estimate
+	if old == "" {
+		old = "(none)"
+	}
+	if strings.TrimSpace(value) == "" {
+		if issue.Estimate == "" {
+			return nil
+		}
+		if err := s.vault.PropertyRemove(id, "estimate"); err != nil {
+			return err
+		}
+		if err := s.touchUpdatedAt(id); err != nil {
+			return err
+		}
+		_ = s.appendHistory(id, fmt.Sprintf("estimate: %s -> (none)", old))
+		return nil
+	}
+
+	d, err := model.ParseEffort(value)
+	if err != nil {
+		return validationError(err)
+	}
+	estimate := model.FormatEffort(d)
+	if err := s.vault.PropertySet(id, "estimate", estimate); err != nil {
+		return err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return err
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("estimate: %s -> %s", old, estimate))
+	return nil
+}
+
+// LogTime records work on an issue: it adds d to the spent total and appends
+// a structured time entry to History. author defaults to the issue's
+// assignee, then to the vault's created_by.
+func (s *Store) LogTime(id string, d time.Duration, author, note string) error {
+	if d <= 0 {
+		return fmt.Errorf("logged time must be positive")
+	}
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	if author == "" {
+		author = issue.Assignee
+	}
+	if author == "" {
+		author = s.config.CreatedBy
+	}
+	if strings.ContainsAny(author, " \t:") {
+		return fmt.Errorf("invalid author %q: must not contain spaces or colons", author)
+	}
+
+	spent := model.FormatEffort(issue.SpentDuration() + d)
+	if err := s.vault.PropertySet(id, "spent", spent); err != nil {
+		return err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return err
+	}
+	return s.appendTimeEntry(id, model.FormatTimeEntry(d, author, note))
+}
+
+// appendTimeEntry adds a timestamped line at the end of the History section
+// by rewriting the body, so earlier entries in the section are always kept:
+// reports are built from every time entry, not just the latest.
+func (s *Store) appendTimeEntry(id, entry string) error {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	line := fmt.Sprintf("- %s %s", time.Now().UTC().Format(time.RFC3339), entry)
+
+	lines := strings.Split(issue.Body, "\n")
+	start := -1
+	for i, l := range lines {
+		if strings.TrimRight(l, " ") == "## History" {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		// No History section yet: let appendHistory create it.
+		return s.appendHistory(id, entry)
+	}
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "## ") {
+			end = i
+			break
+		}
+	}
+	at := end
+	for at > start+1 && strings.TrimSpace(lines[at-1]) == "" {
+		at--
+	}
+	out := append([]string{}, lines[:at]...)
+	out = append(out, line)
+	if at == end && end < len(lines) {
+		out = append(out, "") // keep a blank line before the next heading
+	}
+	out = append(out, lines[at:]...)
+	return s.vault.Write(id, strings.Join(out, "\n"), false)
+}
+
+// TimeReport is the logged time of one author over a date range.
+type TimeReport struct {
+	Author  string
+	Total   time.Duration
+	Entries int
+	Issues  []string // issues with time logged in the range, sorted
+}
+
+// TimeByAuthor sums time entries logged in [from, to) across issues, one
+// report per author sorted by total descending. Zero bounds are open.
+func TimeByAuthor(issues []*model.Issue, from, to time.Time) []TimeReport {
+	byAuthor := make(map[string]*TimeReport)
+	seen := make(map[string]map[string]bool)
+	for _, issue := range issues {
+		for _, e := range issue.TimeLog() {
+			if (!from.IsZero() && e.At.Before(from)) || (!to.IsZero() && !e.At.Before(to)) {
+				continue
+			}
+			r := byAuthor[e.Author]
+			if r == nil {
+				r = &TimeReport{Author: e.Author}
+				byAuthor[e.Author] = r
+				seen[e.Author] = make(map[string]bool)
+			}
+			r.Total += e.Duration
+			r.Entries++
+			if !seen[e.Author][issue.ID] {
+				seen[e.Author][issue.ID] = true
+				r.Issues = append(r.Issues, issue.ID)
+			}
+		}
+	}
+	out := make([]TimeReport, 0, len(byAuthor))
+	for _, r := range byAuthor {
+		sort.Strings(r.Issues)
+		out = append(out, *r)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total != out[j].Total {
+			return out[i].Total > out[j].Total
+		}
+		return out[i].Author < out[j].Author
+	})
+	return out
+}
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLogTimeAccumulatesAndReports(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	a, _ := s.CreateIssue("Parser", "", "task", 2, "alice", nil, "", CreateOptions{Estimate: "4h"})
+	b, _ := s.CreateIssue("Docs", "", "task", 2, "", nil, "")
+
+	if err := s.LogTime(a.ID, 90*time.Minute, "", "first pass"); err != nil {
+		t.Fatalf("LogTime: %v", err)
+	}
+	if err := s.LogTime(a.ID, time.Hour, "bob", "review"); err != nil {
+		t.Fatalf("LogTime: %v", err)
+	}
+	if err := s.LogTime(b.ID, 30*time.Minute, "", ""); err != nil {
+		t.Fatalf("LogTime: %v", err)
+	}
+	if err := s.LogTime(b.ID, time.Hour, "two words", ""); err == nil {
+		t.Error("author with spaces should be rejected")
+	}
+
+	got := mustRead(t, s, a.ID)
+	if got.Spent != "2h30m" || got.Estimate != "4h" {
+		t.Errorf("spent %q of %q, want 2h30m of 4h", got.Spent, got.Estimate)
+	}
+	if log := got.TimeLog(); len(log) != 2 || log[0].Author != "alice" || log[1].Note != "review" {
+		t.Errorf("TimeLog = %+v", log)
+	}
+
+	all, _ := s.ListIssues(FilterOptions{})
+	reports := TimeByAuthor(all, time.Time{}, time.Time{})
+	if len(reports) != 3 || reports[0].Author != "alice" || reports[0].Total != 90*time.Minute {
+		t.Fatalf("TimeByAuthor = %+v", reports)
+	}
+	if reports[2].Author != "tester" || reports[2].Issues[0] != b.ID {
+		t.Errorf("unassigned work should fall back to created_by: %+v", reports[2])
+	}
+	if future := TimeByAuthor(all, time.Now().Add(time.Hour), time.Time{}); len(future) != 0 {
+		t.Errorf("range after all entries should be empty, got %+v", future)
+	}
+
+	if err := s.SetEstimate(b.ID, "1.5d"); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustRead(t, s, b.ID).Estimate; got != "12h" {
+		t.Errorf("estimate normalized to %q, want 12h", got)
+	}
+	if err := s.SetEstimate(b.ID, "soon"); !errors.Is(err, ErrValidation) {
+		t.Errorf("unparseable estimate: err = %v, want %v", err, ErrValidation)
+	}
+}
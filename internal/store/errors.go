@@ -0,0 +1,155 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// Error kinds. Match them with errors.Is; the error itself keeps its
+// original message and, for NotFoundError and TransitionError, details.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrFSMTransition = errors.New("transition not allowed")
+	ErrLocked        = errors.New("vault locked")
+	ErrValidation    = errors.New("validation failed")
+)
+
+// maxSuggestions caps the similar IDs a NotFoundError lists.
+const maxSuggestions = 5
+
+// NotFoundError reports an issue ID that does not exist.
+type NotFoundError struct {
+	ID          string
+	Suggestions []string // existing IDs close to ID, closest first
+}
+
+func (e *NotFoundError) Error() string {
+	msg := fmt.Sprintf("issue %s not found", e.ID)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean " + strings.Join(e.Suggestions, ", ") + "?"
+	}
+	return msg
+}
+
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// TransitionError reports a status change refused by the FSM, a guard, a
+// WIP limit, or an acceptance-criteria gate.
+type TransitionError struct {
+	ID      string
+	From    model.Status
+	To      model.Status
+	Allowed []model.Status // statuses the issue may move to instead
+	Err     error
+}
+
+func (e *TransitionError) Error() string { return e.Err.Error() }
+
+func (e *TransitionError) Unwrap() error { return e.Err }
+
+func (e *TransitionError) Is(target error) bool { return target == ErrFSMTransition }
+
+// kindError tags err with an error kind without changing its message.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() []error { return []error{e.kind, e.err} }
+
+func validationError(err error) error { return &kindError{kind: ErrValidation, err: err} }
+
+func lockedError(err error) error { return &kindError{kind: ErrLocked, err: err} }
+
+// NotFound returns a NotFoundError for id, suggesting similar issue IDs.
+func (s *Store) NotFound(id string) error {
+	return &NotFoundError{ID: id, Suggestions: s.similarIDs(id)}
+}
+
+// refuse wraps a transition refusal with the statuses the issue's workflow
+// permits. It cannot use AllowedTransitions, whose checks call refuse.
+func (s *Store) refuse(issue *model.Issue, to model.Status, err error) error {
+	allowed := []model.Status{}
+	for _, t := range s.workflowMoves(issue) {
+		allowed = append(allowed, t.To)
+	}
+	return &TransitionError{ID: issue.ID, From: issue.Status, To: to, Allowed: allowed, Err: err}
+}
+
+// issueIDs lists the IDs of all issue files, unsorted.
+func (s *Store) issueIDs() []string {
+	entries, err := os.ReadDir(filepath.Join(s.dir, "issues"))
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && strings.HasSuffix(name, ".md") {
+			ids = append(ids, strings.TrimSuffix(name, ".md"))
+		}
+	}
+	return ids
+}
+
+// similarIDs returns existing IDs that differ from id only in case, that
+// contain it, or that are within a small edit distance of it.
+func (s *Store) similarIDs(id string) []string {
+	want := strings.ToLower(id)
+	limit := max(1, len(want)/4)
+	type match struct {
+		id   string
+		dist int
+	}
+	var matches []match
+	for _, candidate := range s.issueIDs() {
+		lower := strings.ToLower(candidate)
+		d := EditDistance(want, lower)
+		if want != "" && strings.Contains(lower, want) {
+			d = 0
+		}
+		if d <= limit {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].id < matches[j].id
+	})
+	var out []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		out = append(out, matches[i].id)
+	}
+	return out
+}
+
+// EditDistance is the Levenshtein distance between a and b, used to suggest
+// the closest name for a typo.
+func EditDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,86 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestErrorKinds(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+	issue, err := s.CreateIssue("Task", "", "task", 2, "", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = s.ReadIssue(issue.ID + "x")
+	var notFound *NotFoundError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &notFound) {
+		t.Fatalf("missing issue: %v", err)
+	}
+	if len(notFound.Suggestions) != 1 || notFound.Suggestions[0] != issue.ID {
+		t.Errorf("suggestions = %v, want [%s]", notFound.Suggestions, issue.ID)
+	}
+	if _, err := s.ReadIssue("ZZZ-nothing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unrelated ID: %v", err)
+	} else if errors.As(err, &notFound) && len(notFound.Suggestions) != 0 {
+		t.Errorf("unrelated ID should have no suggestions: %v", notFound.Suggestions)
+	}
+
+	for _, kv := range [][2]string{
+		{"status.sequence", "open,in_progress,closed"},
+		{"status.fsm", "true"},
+	} {
+		if err := s.SetConfigValue(kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = s.UpdateStatus(issue.ID, model.StatusClosed)
+	var transition *TransitionError
+	if !errors.Is(err, ErrFSMTransition) || !errors.As(err, &transition) {
+		t.Fatalf("skipping a step: %v", err)
+	}
+	if transition.From != model.StatusOpen || transition.To != model.StatusClosed {
+		t.Errorf("transition = %s -> %s", transition.From, transition.To)
+	}
+	if !containsStatus(transition.Allowed, model.StatusInProgress) || containsStatus(transition.Allowed, model.StatusClosed) {
+		t.Errorf("allowed = %v", transition.Allowed)
+	}
+
+	if err := s.SetConfigValue("bogus.key", "x"); !errors.Is(err, ErrValidation) {
+		t.Errorf("unknown config key: %v", err)
+	}
+	if err := s.AddDependency(issue.ID, issue.ID); !errors.Is(err, ErrValidation) {
+		t.Errorf("self dependency: %v", err)
+	}
+	if err := s.AddDependency(issue.ID, "TST-none"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing dependency: %v", err)
+	}
+}
+
+func containsStatus(list []model.Status, st model.Status) bool {
+	for _, s := range list {
+		if s == st {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3}, {"status", "staus", 1}, {"kitten", "sitting", 3}, {"same", "same", 0},
+	} {
+		if got := EditDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("EditDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
+)
+
+// guardContext is the input to a transition guard.
+type guardContext struct {
+	Issue  *model.Issue
+	To     model.Status
+	Reason string // close reason supplied with the transition, if any
+	Graph  *graph.Graph
+}
+
+// guardDef describes a named guard condition. Check returns nil when the
+// condition holds, or an actionable message describing what is missing.
+type guardDef struct {
+	Description string
+	Check       func(ctx guardContext) error
+}
+
+// guardRegistry holds every guard usable in status.guards.
+var guardRegistry = map[string]guardDef{
+	"assignee": {
+		Description: "issue has an assignee",
+		Check: func(ctx guardContext) error {
+			if strings.TrimSpace(ctx.Issue.Assignee) == "" {
+				return fmt.Errorf("no assignee; set one with nd update %s --assignee=<name>", ctx.Issue.ID)
+			}
+			return nil
+		},
+	},
+	"notes": {
+		Description: "## Notes section is non-empty",
+		Check: func(ctx guardContext) error {
+			if strings.TrimSpace(model.Section(ctx.Issue.Body, "Notes")) == "" {
+				return fmt.Errorf("## Notes is empty; add notes with nd update %s --append-notes=\"...\"", ctx.Issue.ID)
+			}
+			return nil
+		},
+	},
+	"close_reason": {
+		Description: "a close reason is given",
+		Check: func(ctx guardContext) error {
+			if strings.TrimSpace(ctx.Reason) == "" && strings.TrimSpace(ctx.Issue.CloseReason) == "" {
+				return fmt.Errorf("no close reason; use nd close %s --reason=\"...\"", ctx.Issue.ID)
+			}
+			return nil
+		},
+	},
+	"children_closed": {
+		Description: "all child issues are closed",
+		Check: func(ctx guardContext) error {
+			var open []string
+			for _, child := range ctx.Graph.Children(ctx.Issue.ID) {
+				if child.IsOpen() {
+					open = append(open, child.ID)
+				}
+			}
+			if len(open) > 0 {
+				return fmt.Errorf("%d child issue(s) still open (%s); close them first", len(open), strings.Join(open, ", "))
+			}
+			return nil
+		},
+	},
+	"no_open_blockers": {
+		Description: "no blocked_by issue is still open",
+		Check: func(ctx guardContext) error {
+			blockers := ctx.Graph.BlockersOf(ctx.Issue.ID)
+			if len(blockers) == 0 {
+				return nil
+			}
+			ids := make([]string, len(blockers))
+			for i, b := range blockers {
+				ids[i] = b.ID
+			}
+			sort.Strings(ids)
+			return fmt.Errorf("blocked by open issue(s) %s; close them or remove the dependency with nd dep rm", strings.Join(ids, ", "))
+		},
+	},
+}
+
+// GuardNames returns the names of all supported guards, sorted.
+func GuardNames() []string {
+	names := make([]string, 0, len(guardRegistry))
+	for name := range guardRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GuardDescription returns the human-readable description of a guard.
+func GuardDescription(name string) string {
+	return guardRegistry[name].Description
+}
+
+// Guards parses the status_guards config into a map of target status -> guard names.
+// Format: "closed:children_closed,close_reason;in_progress:assignee"
+func (s *Store) Guards() map[model.Status][]string {
+	return parseGuards(s.config.StatusGuards)
+}
+
+func parseGuards(raw string) map[model.Status][]string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	result := make(map[model.Status][]string)
+	for _, rule := range strings.Split(raw, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		parts := strings.SplitN(rule, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		to := model.Status(strings.TrimSpace(strings.ToLower(parts[0])))
+		for _, name := range strings.Split(parts[1], ",") {
+			name = strings.TrimSpace(strings.ToLower(name))
+			if name != "" {
+				result[to] = append(result[to], name)
+			}
+		}
+	}
+	return result
+}
+
+// validateGuardsValue checks a status.guards value for unknown statuses and guards.
+func validateGuardsValue(value string, custom []model.Status) error {
+	for _, rule := range strings.Split(value, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		parts := strings.SplitN(rule, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid guard rule %q: expected format status:guard1,guard2", rule)
+		}
+		to := strings.TrimSpace(strings.ToLower(parts[0]))
+		if _, err := model.ParseStatusWithCustom(to, custom); err != nil {
+			return fmt.Errorf("invalid status %q in guard rule: %w", to, err)
+		}
+		for _, name := range strings.Split(parts[1], ",") {
+			name = strings.TrimSpace(strings.ToLower(name))
+			if name == "" {
+				continue
+			}
+			if _, ok := guardRegistry[name]; !ok {
+				return fmt.Errorf("unknown guard %q for %s: must be one of %s", name, to, strings.Join(GuardNames(), ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// checkGuards evaluates every guard configured for the target status and
+// returns a single error listing all violations.
+func (s *Store) checkGuards(issue *model.Issue, to model.Status, reason string) error {
+	names := s.Guards()[to]
+	if len(names) == 0 {
+		return nil
+	}
+
+	all, err := s.ListIssues(FilterOptions{})
+	if err != nil {
+		return fmt.Errorf("load issues for guards: %w", err)
+	}
+	ctx := guardContext{Issue: issue, To: to, Reason: reason, Graph: graph.Build(all)}
+
+	var failures []string
+	for _, name := range names {
+		def, ok := guardRegistry[name]
+		if !ok {
+			continue
+		}
+		if err := def.Check(ctx); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return s.refuse(issue, to, fmt.Errorf("guard failed for %s -> %s:\n  - %s", issue.Status, to, strings.Join(failures, "\n  - ")))
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGuardChildrenClosed(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.guards", "closed:children_closed"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+
+	epic, _ := s.CreateIssue("Epic", "", "epic", 1, "", nil, "")
+	child, _ := s.CreateIssue("Child", "", "task", 2, "", nil, epic.ID)
+
+	err = s.CloseIssue(epic.ID, "done")
+	if err == nil {
+		t.Fatal("closing an epic with an open child should fail")
+	}
+	if !strings.Contains(err.Error(), "children_closed") || !strings.Contains(err.Error(), child.ID) {
+		t.Errorf("error should name the guard and the open child: %v", err)
+	}
+
+	if err := s.CloseIssue(child.ID, "done"); err != nil {
+		t.Fatalf("close child: %v", err)
+	}
+	if err := s.CloseIssue(epic.ID, "done"); err != nil {
+		t.Errorf("close epic after children closed: %v", err)
+	}
+}
+
+func TestGuardsReportAllFailures(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.guards", "in_progress:assignee,notes;closed:close_reason"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+
+	issue, _ := s.CreateIssue("Guarded", "", "task", 2, "", nil, "")
+	err = s.UpdateStatus(issue.ID, "in_progress")
+	if err == nil {
+		t.Fatal("in_progress without assignee or notes should fail")
+	}
+	for _, want := range []string{"assignee:", "notes:"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q: %v", want, err)
+		}
+	}
+
+	if err := s.UpdateField(issue.ID, "assignee", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AppendNotes(issue.ID, "starting"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateStatus(issue.ID, "in_progress"); err != nil {
+		t.Fatalf("UpdateStatus after satisfying guards: %v", err)
+	}
+
+	if err := s.CloseIssue(issue.ID, ""); err == nil || !strings.Contains(err.Error(), "close_reason") {
+		t.Errorf("close without reason should fail on close_reason, got %v", err)
+	}
+	if err := s.CloseIssue(issue.ID, "shipped"); err != nil {
+		t.Errorf("close with reason: %v", err)
+	}
+}
+
+func TestGuardNoOpenBlockers(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.guards", "in_progress:no_open_blockers"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+
+	blocker, _ := s.CreateIssue("Blocker", "", "task", 2, "", nil, "")
+	blocked, _ := s.CreateIssue("Blocked", "", "task", 2, "", nil, "")
+	if err := s.AddDependency(blocked.ID, blocker.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.UpdateStatus(blocked.ID, "in_progress"); err == nil || !strings.Contains(err.Error(), blocker.ID) {
+		t.Errorf("starting a blocked issue should fail naming the blocker, got %v", err)
+	}
+	if err := s.CloseIssue(blocker.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateStatus(blocked.ID, "in_progress"); err != nil {
+		t.Errorf("UpdateStatus after blocker closed: %v", err)
+	}
+}
+
+func TestValidateGuardsValue(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, bad := range []string{"closed:bogus", "nowhere:assignee", "closed"} {
+		if err := s.SetConfigValue("status.guards", bad); err == nil {
+			t.Errorf("status.guards=%q should be rejected", bad)
+		}
+	}
+	if err := s.SetConfigValue("status.guards", "closed:children_closed, close_reason"); err != nil {
+		t.Errorf("valid value rejected: %v", err)
+	}
+	got := s.Guards()["closed"]
+	if strings.Join(got, ",") != "children_closed,close_reason" {
+		t.Errorf("Guards()[closed] = %v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/RamXX/nd/internal/dates"
 	"github.com/RamXX/nd/internal/enforce"
 	"github.com/RamXX/nd/internal/idgen"
 	"github.com/RamXX/nd/internal/model"
@@ -13,45 +14,74 @@ import (
 )
 
 // CreateIssue generates an ID, serializes the issue to markdown, and writes it to the vault.
-func (s *Store) CreateIssue(title, description, issueType string, priority int, assignee string, labels []string, parent string) (*model.Issue, error) {
+func (s *Store) CreateIssue(title, description, issueType string, priority int, assignee string, labels []string, parent string, opts ...CreateOptions) (*model.Issue, error) {
 	id, err := idgen.GenerateID(s.config.Prefix, title, s.IssueExists)
 	if err != nil {
 		return nil, fmt.Errorf("generate ID: %w", err)
 	}
-	return s.createIssue(id, title, description, issueType, priority, assignee, labels, parent)
+	return s.createIssue(id, title, description, issueType, priority, assignee, labels, parent, opts...)
 }
 
 // CreateIssueWithID creates an issue using a pre-determined ID (e.g. from import).
-func (s *Store) CreateIssueWithID(id, title, description, issueType string, priority int, assignee string, labels []string, parent string) (*model.Issue, error) {
-	return s.createIssue(id, title, description, issueType, priority, assignee, labels, parent)
+func (s *Store) CreateIssueWithID(id, title, description, issueType string, priority int, assignee string, labels []string, parent string, opts ...CreateOptions) (*model.Issue, error) {
+	return s.createIssue(id, title, description, issueType, priority, assignee, labels, parent, opts...)
 }
 
-func (s *Store) createIssue(id, title, description, issueType string, priority int, assignee string, labels []string, parent string) (*model.Issue, error) {
+func (s *Store) createIssue(id, title, description, issueType string, priority int, assignee string, labels []string, parent string, opts ...CreateOptions) (*model.Issue, error) {
+	var createOpts CreateOptions
+	if len(opts) > 0 {
+		createOpts = opts[0]
+	}
+
 	itype, err := model.ParseIssueType(issueType)
 	if err != nil {
 		return nil, err
 	}
 
 	now := time.Now().UTC()
-	body := buildBody(description)
 	issue := &model.Issue{
-		ID:          id,
-		Title:       title,
-		Status:      model.StatusOpen,
-		Priority:    model.Priority(priority),
-		Type:        itype,
-		Assignee:    assignee,
-		Labels:      labels,
-		Parent:      parent,
-		CreatedAt:   now,
-		CreatedBy:   s.config.CreatedBy,
-		UpdatedAt:   now,
-		ContentHash: enforce.ComputeContentHash(body),
-		Body:        body,
-	}
+		ID:        id,
+		Title:     title,
+		Status:    model.StatusOpen,
+		Priority:  model.Priority(priority),
+		Type:      itype,
+		Assignee:  assignee,
+		Labels:    labels,
+		Parent:    parent,
+		CreatedAt: now,
+		CreatedBy: s.config.CreatedBy,
+		UpdatedAt: now,
+	}
+	issue.Status = s.startStatus(issue)
+	if createOpts.DeferUntil != "" {
+		issue.Status = model.StatusDeferred
+		issue.DeferUntil = createOpts.DeferUntil
+	}
+	issue.Recur = createOpts.Recur
+	issue.Due = createOpts.Due
+	issue.Estimate = createOpts.Estimate
+	issue.PlanKey = createOpts.PlanKey
+	if issue.Due == "" {
+		// A deferred issue's SLA starts when it wakes, not when it is filed.
+		from := now
+		if t, err := dates.Parse(issue.DeferUntil, now); err == nil {
+			from = t
+		}
+		issue.Due = s.slaDue(issue.Priority, from)
+	}
+	switch {
+	case createOpts.Body != "":
+		issue.Body = createOpts.Body
+	case createOpts.Template != nil:
+		issue.Body = createOpts.Template.Render(issue, description)
+	default:
+		issue.Body = buildBody(description)
+	}
+	issue.ContentHash = enforce.ComputeContentHash(issue.Body)
+	issue.ACProgress = issue.AcceptanceProgress()
 
 	if err := issue.ValidateWithCustom(s.CustomStatuses()); err != nil {
-		return nil, fmt.Errorf("validate: %w", err)
+		return nil, validationError(fmt.Errorf("validate: %w", err))
 	}
 
 	content := serializeIssue(issue)
@@ -73,6 +103,9 @@ func (s *Store) createIssue(id, title, description, issueType string, priority i
 
 // ReadIssue reads and deserializes an issue by ID.
 func (s *Store) ReadIssue(id string) (*model.Issue, error) {
+	if !s.IssueExists(id) {
+		return nil, s.NotFound(id)
+	}
 	content, err := s.vault.Read(id, "")
 	if err != nil {
 		return nil, fmt.Errorf("read %s: %w", id, err)
@@ -186,6 +219,24 @@ func marshalFrontmatter(issue *model.Issue) string {
 	if issue.DeferUntil != "" {
 		sb.WriteString(fmt.Sprintf("defer_until: %s\n", issue.DeferUntil))
 	}
+	if issue.Recur != "" {
+		sb.WriteString(fmt.Sprintf("recur: %q\n", issue.Recur))
+	}
+	if issue.Due != "" {
+		sb.WriteString(fmt.Sprintf("due: %s\n", issue.Due))
+	}
+	if issue.Estimate != "" {
+		sb.WriteString(fmt.Sprintf("estimate: %s\n", issue.Estimate))
+	}
+	if issue.Spent != "" {
+		sb.WriteString(fmt.Sprintf("spent: %s\n", issue.Spent))
+	}
+	if issue.LeaseUntil != "" {
+		sb.WriteString(fmt.Sprintf("lease_until: %s\n", issue.LeaseUntil))
+	}
+	if issue.PlanKey != "" {
+		sb.WriteString(fmt.Sprintf("plan_key: %q\n", issue.PlanKey))
+	}
 	sb.WriteString(fmt.Sprintf("created_at: %s\n", issue.CreatedAt.Format(time.RFC3339)))
 	sb.WriteString(fmt.Sprintf("created_by: %s\n", issue.CreatedBy))
 	sb.WriteString(fmt.Sprintf("updated_at: %s\n", issue.UpdatedAt.Format(time.RFC3339)))
@@ -278,5 +329,6 @@ func deserializeIssue(content string) (*model.Issue, error) {
 	if bodyStart < len(lines) {
 		issue.Body = strings.Join(lines[bodyStart:], "")
 	}
+	issue.ACProgress = issue.AcceptanceProgress()
 	return &issue, nil
 }
@@ -0,0 +1,208 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/graph"
+	"github.com/RamXX/nd/internal/model"
+)
+
+// DefaultLease is how long a claim lasts without a heartbeat.
+const DefaultLease = time.Hour
+
+// ClaimOptions selects and scopes an nd claim.
+type ClaimOptions struct {
+	Actor string        // who takes the issue; required
+	Lease time.Duration // lease length; DefaultLease when zero
+	Label string        // when picking from ready work, only issues with this label
+}
+
+// ClaimNext takes the highest-priority ready issue (oldest first within a
+// priority) that is open and unassigned or already assigned to the actor.
+// The store's vault lock makes the pick and the claim one atomic step, so
+// concurrent claims never return the same issue. A candidate that a guard,
+// WIP limit, or acceptance gate refuses is skipped for the next one. Returns
+// nil when no ready issue qualifies, and the first refusal when every
+// candidate was refused.
+func (s *Store) ClaimNext(opts ClaimOptions) (*model.Issue, error) {
+	if opts.Actor == "" {
+		return nil, validationError(fmt.Errorf("claim needs an actor (--as or ND_ACTOR)"))
+	}
+	all, err := s.ListIssues(FilterOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ready := graph.Build(all).Ready()
+	SortIssues(ready, "created", false)
+	SortIssues(ready, "priority", false)
+	var refused error
+	for _, issue := range ready {
+		if issue.Status != model.StatusOpen || issue.Type == model.TypeEpic {
+			continue
+		}
+		if issue.Assignee != "" && issue.Assignee != opts.Actor {
+			continue
+		}
+		if opts.Label != "" && !hasLabel(issue, opts.Label) {
+			continue
+		}
+		claimed, err := s.Claim(issue.ID, opts)
+		if err == nil {
+			return claimed, nil
+		}
+		// A guard, WIP limit, or gate refusing this issue leaves the next one
+		// claimable; anything else is a real failure.
+		if !errors.Is(err, ErrFSMTransition) {
+			return nil, err
+		}
+		if refused == nil {
+			refused = err
+		}
+	}
+	return nil, refused
+}
+
+func hasLabel(issue *model.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// Claim assigns an issue to the actor, moves it to in_progress, and records
+// a lease. Issues assigned to someone else can only be taken over once
+// their lease has lapsed.
+func (s *Store) Claim(id string, opts ClaimOptions) (*model.Issue, error) {
+	if opts.Actor == "" {
+		return nil, validationError(fmt.Errorf("claim needs an actor (--as or ND_ACTOR)"))
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = DefaultLease
+	}
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if issue.Status == model.StatusClosed {
+		return nil, s.refuse(issue, model.StatusInProgress, fmt.Errorf("issue %s is closed", id))
+	}
+	// Another actor's live lease or assignment holds the issue like a lock.
+	if issue.Assignee != "" && issue.Assignee != opts.Actor && !issue.LeaseExpired(now) {
+		if issue.LeaseActive(now) {
+			return nil, lockedError(fmt.Errorf("issue %s is claimed by %s until %s", id, issue.Assignee, issue.LeaseUntil))
+		}
+		return nil, lockedError(fmt.Errorf("issue %s is assigned to %s", id, issue.Assignee))
+	}
+
+	prevAssignee := issue.Assignee
+	if prevAssignee != opts.Actor {
+		if err := s.vault.PropertySet(id, "assignee", opts.Actor); err != nil {
+			return nil, err
+		}
+	}
+	if issue.Status != model.StatusInProgress {
+		if err := s.UpdateStatus(id, model.StatusInProgress); err != nil {
+			if prevAssignee != opts.Actor {
+				s.restoreAssignee(id, prevAssignee)
+			}
+			return nil, err
+		}
+	}
+	until := now.Add(opts.Lease).Format(time.RFC3339)
+	if err := s.vault.PropertySet(id, "lease_until", until); err != nil {
+		return nil, err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return nil, err
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("claimed by %s (lease until %s)", opts.Actor, until))
+	return s.ReadIssue(id)
+}
+
+func (s *Store) restoreAssignee(id, assignee string) {
+	if assignee == "" {
+		_ = s.vault.PropertyRemove(id, "assignee")
+		return
+	}
+	_ = s.vault.PropertySet(id, "assignee", assignee)
+}
+
+// Heartbeat extends the actor's lease on an issue to lease from now.
+// Heartbeats are not written to History; the lease field carries them.
+func (s *Store) Heartbeat(id, actor string, lease time.Duration) (*model.Issue, error) {
+	if lease <= 0 {
+		lease = DefaultLease
+	}
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return nil, err
+	}
+	if issue.LeaseUntil == "" || issue.Status == model.StatusClosed {
+		return nil, validationError(fmt.Errorf("issue %s has no lease; claim it with nd claim %s", id, id))
+	}
+	if actor != "" && issue.Assignee != actor {
+		return nil, lockedError(fmt.Errorf("issue %s is claimed by %s, not %s", id, issue.Assignee, actor))
+	}
+	until := time.Now().UTC().Add(lease).Format(time.RFC3339)
+	if err := s.vault.PropertySet(id, "lease_until", until); err != nil {
+		return nil, err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return nil, err
+	}
+	return s.ReadIssue(id)
+}
+
+// ReapLeases releases every non-closed issue whose lease expired before now:
+// the issue goes back to open, its assignee and lease are cleared, and a
+// history note records who held it. Status is reset directly, since a
+// crashed holder cannot walk the workflow back.
+func (s *Store) ReapLeases(now time.Time) ([]*model.Issue, error) {
+	all, err := s.ListIssues(FilterOptions{Status: "!closed"})
+	if err != nil {
+		return nil, err
+	}
+	var reaped []*model.Issue
+	for _, issue := range all {
+		if !issue.LeaseExpired(now) {
+			continue
+		}
+		if err := s.releaseLease(issue); err != nil {
+			return reaped, fmt.Errorf("release %s: %w", issue.ID, err)
+		}
+		reaped = append(reaped, issue)
+	}
+	return reaped, nil
+}
+
+func (s *Store) releaseLease(issue *model.Issue) error {
+	id := issue.ID
+	if issue.Status != model.StatusOpen {
+		if err := s.vault.PropertySet(id, "status", string(model.StatusOpen)); err != nil {
+			return err
+		}
+	}
+	if issue.Assignee != "" {
+		if err := s.vault.PropertyRemove(id, "assignee"); err != nil {
+			return err
+		}
+	}
+	if err := s.vault.PropertyRemove(id, "lease_until"); err != nil {
+		return err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return err
+	}
+	holder := issue.Assignee
+	if holder == "" {
+		holder = "(unassigned)"
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("lease expired: released from %s (lease until %s), status: %s -> open", holder, issue.LeaseUntil, issue.Status))
+	return nil
+}
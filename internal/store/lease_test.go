@@ -0,0 +1,142 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestClaimNextPicksHighestPriorityReady(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	low, _ := s.CreateIssue("Low", "", "task", 3, "", nil, "")
+	high, _ := s.CreateIssue("High", "", "task", 1, "", []string{"api"}, "")
+	blocked, _ := s.CreateIssue("Blocked", "", "task", 0, "", nil, "")
+	if err := s.AddDependency(blocked.ID, low.ID); err != nil {
+		t.Fatal(err)
+	}
+	taken, _ := s.CreateIssue("Taken", "", "task", 0, "bob", nil, "")
+
+	got, err := s.ClaimNext(ClaimOptions{Actor: "alice", Lease: 30 * time.Minute})
+	if err != nil {
+		t.Fatalf("ClaimNext: %v", err)
+	}
+	if got == nil || got.ID != high.ID {
+		t.Fatalf("claimed %v, want %s (blocked and bob's issues are skipped)", got, high.ID)
+	}
+	if got.Status != model.StatusInProgress || got.Assignee != "alice" || !got.LeaseActive(time.Now()) {
+		t.Errorf("claimed issue: status %s, assignee %q, lease %q", got.Status, got.Assignee, got.LeaseUntil)
+	}
+
+	// A second agent gets the next issue, not the same one.
+	next, err := s.ClaimNext(ClaimOptions{Actor: "carol"})
+	if err != nil {
+		t.Fatalf("ClaimNext: %v", err)
+	}
+	if next == nil || next.ID != low.ID {
+		t.Errorf("second claim = %v, want %s", next, low.ID)
+	}
+	if none, err := s.ClaimNext(ClaimOptions{Actor: "dave", Label: "api"}); err != nil || none != nil {
+		t.Errorf("nothing labeled api is left, got %v, %v", none, err)
+	}
+	if _, err := s.Claim(taken.ID, ClaimOptions{Actor: "alice"}); !errors.Is(err, ErrLocked) || !strings.Contains(err.Error(), "bob") {
+		t.Errorf("claiming bob's issue: err = %v", err)
+	}
+	if _, err := s.Claim(high.ID, ClaimOptions{Actor: "carol"}); !errors.Is(err, ErrLocked) || !strings.Contains(err.Error(), "claimed by alice") {
+		t.Errorf("claiming a leased issue: err = %v", err)
+	}
+}
+
+func TestClaimNextSkipsRefusedIssues(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.guards", "in_progress:notes"); err != nil {
+		t.Fatalf("set guards: %v", err)
+	}
+	first, _ := s.CreateIssue("First", "", "task", 1, "", nil, "")
+	second, _ := s.CreateIssue("Second", "", "task", 2, "", nil, "")
+	if err := s.AppendNotes(second.ID, "plan written"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.ClaimNext(ClaimOptions{Actor: "alice"})
+	if err != nil {
+		t.Fatalf("ClaimNext: %v", err)
+	}
+	if got == nil || got.ID != second.ID {
+		t.Fatalf("claimed %v, want %s (%s has no notes)", got, second.ID, first.ID)
+	}
+	if read := mustRead(t, s, first.ID); read.Assignee != "" || read.Status != model.StatusOpen {
+		t.Errorf("refused issue was left changed: assignee %q, status %s", read.Assignee, read.Status)
+	}
+	if _, err := s.ClaimNext(ClaimOptions{Actor: "bob"}); !errors.Is(err, ErrFSMTransition) {
+		t.Errorf("every candidate refused: err = %v, want the refusal", err)
+	}
+}
+
+func TestHeartbeatAndReap(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, _ := s.CreateIssue("Work", "", "task", 2, "", nil, "")
+	claimed, err := s.Claim(issue.ID, ClaimOptions{Actor: "alice", Lease: time.Minute})
+	if err != nil {
+		t.Fatalf("Claim: %v", err)
+	}
+	if _, err := s.Heartbeat(issue.ID, "bob", time.Hour); err == nil {
+		t.Error("heartbeat by a non-holder should fail")
+	}
+	beat, err := s.Heartbeat(issue.ID, "alice", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("Heartbeat: %v", err)
+	}
+	before, _ := claimed.LeaseExpiry()
+	after, _ := beat.LeaseExpiry()
+	if !after.After(before) {
+		t.Errorf("lease not extended: %s -> %s", claimed.LeaseUntil, beat.LeaseUntil)
+	}
+
+	if reaped, err := s.ReapLeases(time.Now()); err != nil || len(reaped) != 0 {
+		t.Fatalf("live lease reaped: %v, %v", reaped, err)
+	}
+	reaped, err := s.ReapLeases(time.Now().Add(3 * time.Hour))
+	if err != nil || len(reaped) != 1 {
+		t.Fatalf("ReapLeases = %v, %v; want 1", reaped, err)
+	}
+	got := mustRead(t, s, issue.ID)
+	if got.Status != model.StatusOpen || got.Assignee != "" || got.LeaseUntil != "" {
+		t.Errorf("reaped issue: status %s, assignee %q, lease %q", got.Status, got.Assignee, got.LeaseUntil)
+	}
+	if !strings.Contains(model.Section(got.Body, "History"), "lease expired") {
+		t.Error("reap should leave a history note")
+	}
+}
+
+func TestLeaseEndsWhenWorkMovesOn(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, _ := s.CreateIssue("Work", "", "task", 2, "", nil, "")
+	if _, err := s.Claim(issue.ID, ClaimOptions{Actor: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CloseIssue(issue.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustRead(t, s, issue.ID); got.LeaseUntil != "" {
+		t.Errorf("closed issue kept lease %q", got.LeaseUntil)
+	}
+}
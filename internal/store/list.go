@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/RamXX/nd/internal/dates"
 	"github.com/RamXX/nd/internal/model"
 )
 
@@ -22,7 +23,9 @@ type FilterOptions struct {
 	CreatedBefore time.Time
 	UpdatedAfter  time.Time
 	UpdatedBefore time.Time
-	Sort          string // "priority", "created", "updated", "id" (default)
+	DeferAfter    time.Time // deferred issues waking after this time
+	DeferBefore   time.Time // deferred issues waking before this time
+	Sort          string    // "priority", "created", "updated", "due", "id" (default)
 	Reverse       bool
 	Limit         int
 }
@@ -145,6 +148,21 @@ func (s *Store) MatchesFilter(issue *model.Issue, opts FilterOptions) bool {
 	if !opts.UpdatedBefore.IsZero() && !issue.UpdatedAt.Before(opts.UpdatedBefore) {
 		return false
 	}
+	if !opts.DeferAfter.IsZero() || !opts.DeferBefore.IsZero() {
+		if issue.Status != model.StatusDeferred || issue.DeferUntil == "" {
+			return false
+		}
+		wake, err := dates.Parse(issue.DeferUntil, time.Now())
+		if err != nil {
+			return false
+		}
+		if !opts.DeferAfter.IsZero() && !wake.After(opts.DeferAfter) {
+			return false
+		}
+		if !opts.DeferBefore.IsZero() && !wake.Before(opts.DeferBefore) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -159,6 +177,16 @@ func SortIssues(issues []*model.Issue, sortBy string, reverse bool) {
 		less = func(a, b *model.Issue) bool { return a.CreatedAt.Before(b.CreatedAt) }
 	case "updated":
 		less = func(a, b *model.Issue) bool { return a.UpdatedAt.After(b.UpdatedAt) }
+	case "due":
+		// Earliest deadline first; issues without a due date sort last.
+		less = func(a, b *model.Issue) bool {
+			da, okA := a.Deadline()
+			db, okB := b.Deadline()
+			if okA != okB {
+				return okA
+			}
+			return okA && da.Before(db)
+		}
 	}
 	if reverse {
 		orig := less
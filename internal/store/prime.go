@@ -0,0 +1,316 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// PrimeSections lists every section a prime profile may choose.
+var PrimeSections = []string{
+	"summary", "changed", "overdue", "ready", "in_progress", "blocked",
+	"recently_closed", "epics", "stale", "rules",
+}
+
+// DefaultPrimeProfile is the name of the profile nd prime uses without --profile.
+const DefaultPrimeProfile = "default"
+
+// primeStateFile records when each actor last ran nd prime.
+const primeStateFile = ".nd-prime-state.yaml"
+
+// PrimeProfile selects the sections and limits of nd prime output. It is
+// stored in .nd.yaml under prime.<name>.
+type PrimeProfile struct {
+	Sections   []string `yaml:"sections,omitempty"`    // in display order
+	Limit      int      `yaml:"limit,omitempty"`       // items per section; 0 means all
+	Mine       bool     `yaml:"mine,omitempty"`        // scope ready and in-progress work to the actor
+	ClosedDays int      `yaml:"closed_days,omitempty"` // recently_closed window; default 7
+	StaleDays  int      `yaml:"stale_days,omitempty"`  // stale threshold; default 14
+}
+
+// builtinPrimeProfile reproduces the classic nd prime output.
+func builtinPrimeProfile() PrimeProfile {
+	return PrimeProfile{Sections: []string{"summary", "overdue", "ready", "blocked", "in_progress"}}
+}
+
+// PrimeProfileNames returns the configured profile names plus the default, sorted.
+func (s *Store) PrimeProfileNames() []string {
+	names := []string{DefaultPrimeProfile}
+	for name := range s.config.Prime {
+		if name != DefaultPrimeProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// PrimeProfile returns a profile by name with defaults filled in. The
+// default profile may be overridden in config; unset sections fall back to
+// the classic set.
+func (s *Store) PrimeProfile(name string) (PrimeProfile, error) {
+	if name == "" {
+		name = DefaultPrimeProfile
+	}
+	p, ok := s.config.Prime[name]
+	if !ok {
+		if name != DefaultPrimeProfile {
+			return PrimeProfile{}, fmt.Errorf("unknown prime profile %q: must be one of %s", name, strings.Join(s.PrimeProfileNames(), ", "))
+		}
+		p = builtinPrimeProfile()
+	}
+	if len(p.Sections) == 0 {
+		p.Sections = builtinPrimeProfile().Sections
+	}
+	if p.ClosedDays <= 0 {
+		p.ClosedDays = 7
+	}
+	if p.StaleDays <= 0 {
+		p.StaleDays = 14
+	}
+	return p, nil
+}
+
+// validatePrimeSections checks a comma-separated prime.<name>.sections value.
+func validatePrimeSections(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !contains(PrimeSections, name) {
+			return fmt.Errorf("unknown prime section %q: must be one of %s", name, strings.Join(PrimeSections, ", "))
+		}
+	}
+	return nil
+}
+
+// setPrimeValue handles prime.<name>.<field>. Clearing every field removes
+// the profile.
+func (s *Store) setPrimeValue(key, value string) error {
+	rest := strings.TrimPrefix(key, "prime.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return fmt.Errorf("invalid prime key %q: expected prime.<profile>.<sections|limit|mine|closed_days|stale_days>", key)
+	}
+	name, field := rest[:dot], rest[dot+1:]
+	if !validConfigKeyRe.MatchString(name) {
+		return fmt.Errorf("invalid prime profile name %q: must be lowercase alphanumeric/underscore", name)
+	}
+
+	p := s.config.Prime[name]
+	value = strings.TrimSpace(value)
+	switch field {
+	case "sections":
+		if err := validatePrimeSections(value); err != nil {
+			return err
+		}
+		p.Sections = nil
+		for _, sec := range strings.Split(value, ",") {
+			if sec = strings.TrimSpace(sec); sec != "" {
+				p.Sections = append(p.Sections, sec)
+			}
+		}
+	case "mine":
+		b := false
+		if value != "" {
+			var err error
+			if b, err = strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("invalid value for %s: must be true or false", key)
+			}
+		}
+		p.Mine = b
+	case "limit", "closed_days", "stale_days":
+		n := 0
+		if value != "" {
+			var err error
+			if n, err = strconv.Atoi(value); err != nil || n < 0 {
+				return fmt.Errorf("invalid value for %s: must be a non-negative number", key)
+			}
+		}
+		switch field {
+		case "limit":
+			p.Limit = n
+		case "closed_days":
+			p.ClosedDays = n
+		default:
+			p.StaleDays = n
+		}
+	default:
+		return fmt.Errorf("unknown prime field %q: must be sections, limit, mine, closed_days, or stale_days", field)
+	}
+
+	if len(p.Sections) == 0 && p.Limit == 0 && !p.Mine && p.ClosedDays == 0 && p.StaleDays == 0 {
+		delete(s.config.Prime, name)
+		return nil
+	}
+	if s.config.Prime == nil {
+		s.config.Prime = make(map[string]PrimeProfile)
+	}
+	s.config.Prime[name] = p
+	return nil
+}
+
+// getPrimeValue returns prime.<name>.<field> as stored (defaults not applied).
+func (s *Store) getPrimeValue(key string) (string, error) {
+	rest := strings.TrimPrefix(key, "prime.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+	entries := primeEntries(rest[:dot], s.config.Prime[rest[:dot]])
+	for _, e := range entries {
+		if e[0] == key {
+			return e[1], nil
+		}
+	}
+	switch rest[dot+1:] {
+	case "sections", "limit", "mine", "closed_days", "stale_days":
+		return "", nil
+	}
+	return "", fmt.Errorf("unknown config key %q", key)
+}
+
+// primeEntries lists the set fields of a profile as config key/value pairs.
+func primeEntries(name string, p PrimeProfile) [][2]string {
+	prefix := "prime." + name + "."
+	var out [][2]string
+	if len(p.Sections) > 0 {
+		out = append(out, [2]string{prefix + "sections", strings.Join(p.Sections, ",")})
+	}
+	if p.Limit > 0 {
+		out = append(out, [2]string{prefix + "limit", strconv.Itoa(p.Limit)})
+	}
+	if p.Mine {
+		out = append(out, [2]string{prefix + "mine", "true"})
+	}
+	if p.ClosedDays > 0 {
+		out = append(out, [2]string{prefix + "closed_days", strconv.Itoa(p.ClosedDays)})
+	}
+	if p.StaleDays > 0 {
+		out = append(out, [2]string{prefix + "stale_days", strconv.Itoa(p.StaleDays)})
+	}
+	return out
+}
+
+// LastPrime returns when actor last ran nd prime, or false when never.
+func (s *Store) LastPrime(actor string) (time.Time, bool) {
+	state := s.readPrimeState()
+	t, err := time.Parse(time.RFC3339, state[actor])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// RecordPrime stores at as actor's last nd prime. The state file is local
+// runtime state and is git-ignored.
+func (s *Store) RecordPrime(actor string, at time.Time) error {
+	if actor == "" {
+		return nil
+	}
+	state := s.readPrimeState()
+	state[actor] = at.UTC().Format(time.RFC3339)
+	data, err := yaml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, primeStateFile), data, 0o644)
+}
+
+func (s *Store) readPrimeState() map[string]string {
+	state := make(map[string]string)
+	data, err := os.ReadFile(filepath.Join(s.dir, primeStateFile))
+	if err != nil {
+		return state
+	}
+	_ = yaml.Unmarshal(data, &state)
+	return state
+}
+
+// RulesSummary describes the workflow rules in force as plain sentences,
+// for agents that need to know what moves are allowed.
+func (s *Store) RulesSummary() []string {
+	var lines []string
+	if !s.config.StatusFSM {
+		lines = append(lines, "Status changes are not restricted (FSM disabled); any status can move to any other.")
+	} else {
+		lines = append(lines, describeWorkflow(s.workflowOrDefault(DefaultWorkflow), "Issues")...)
+		for _, name := range s.WorkflowNames()[1:] {
+			who := "Issues of type " + name
+			if label, ok := strings.CutPrefix(name, "label:"); ok {
+				who = "Issues labeled " + label
+			}
+			lines = append(lines, describeWorkflow(s.workflowOrDefault(name), who)...)
+		}
+	}
+	lines = append(lines, "Closed issues can only be reopened (moved back to open).")
+
+	for _, g := range s.ACGates() {
+		if g.From == "" {
+			lines = append(lines, fmt.Sprintf("Every acceptance criterion must be checked before moving to %s.", g.To))
+		} else {
+			lines = append(lines, fmt.Sprintf("Every acceptance criterion must be checked before moving from %s to %s.", g.From, g.To))
+		}
+	}
+	guards := s.Guards()
+	for _, to := range sortedStatusKeys(guards) {
+		var parts []string
+		for _, name := range guards[to] {
+			parts = append(parts, GuardDescription(name))
+		}
+		lines = append(lines, fmt.Sprintf("Moving to %s requires: %s.", to, strings.Join(parts, "; ")))
+	}
+	limits, perUser := s.WIPLimits(), s.AssigneeWIPLimits()
+	for _, st := range s.AllStatuses() {
+		switch n, m := limits[st], perUser[st]; {
+		case n > 0 && m > 0:
+			lines = append(lines, fmt.Sprintf("At most %d issues may be %s at once, and at most %d per assignee.", n, st, m))
+		case n > 0:
+			lines = append(lines, fmt.Sprintf("At most %d issues may be %s at once.", n, st))
+		case m > 0:
+			lines = append(lines, fmt.Sprintf("Each assignee may have at most %d issues %s.", m, st))
+		}
+	}
+	return lines
+}
+
+func (s *Store) workflowOrDefault(name string) Workflow {
+	wf, err := s.Workflow(name)
+	if err != nil {
+		wf, _ = s.Workflow(DefaultWorkflow)
+	}
+	return wf
+}
+
+func describeWorkflow(wf Workflow, who string) []string {
+	var lines []string
+	if len(wf.Sequence) > 0 {
+		names := make([]string, len(wf.Sequence))
+		for i, st := range wf.Sequence {
+			names[i] = string(st)
+		}
+		lines = append(lines, fmt.Sprintf("%s move forward one step at a time through %s, and may go back to any earlier step.", who, strings.Join(names, " -> ")))
+	}
+	for _, from := range sortedStatusKeys(wf.ExitRules) {
+		names := make([]string, len(wf.ExitRules[from]))
+		for i, st := range wf.ExitRules[from] {
+			names[i] = string(st)
+		}
+		lines = append(lines, fmt.Sprintf("%s in %s may only move to %s.", who, from, strings.Join(names, ", ")))
+	}
+	return lines
+}
+
+func sortedStatusKeys[V any](m map[model.Status]V) []model.Status {
+	keys := make([]model.Status, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
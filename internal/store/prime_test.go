@@ -0,0 +1,109 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrimeProfileConfig(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("prime.agent.sections", "changed, ready,rules"); err != nil {
+		t.Fatalf("set sections: %v", err)
+	}
+	if err := s.SetConfigValue("prime.agent.limit", "5"); err != nil {
+		t.Fatalf("set limit: %v", err)
+	}
+	if err := s.SetConfigValue("prime.agent.sections", "ready,bogus"); err == nil {
+		t.Error("unknown section should be rejected")
+	}
+	s.Close()
+
+	s, err = Open(dir)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer s.Close()
+	if got, _ := s.GetConfigValue("prime.agent.sections"); got != "changed,ready,rules" {
+		t.Errorf("sections = %q", got)
+	}
+	p, err := s.PrimeProfile("agent")
+	if err != nil {
+		t.Fatalf("PrimeProfile: %v", err)
+	}
+	if p.Limit != 5 || p.ClosedDays != 7 || p.StaleDays != 14 {
+		t.Errorf("profile = %+v", p)
+	}
+	if def, _ := s.PrimeProfile(""); strings.Join(def.Sections, ",") != "summary,overdue,ready,blocked,in_progress" {
+		t.Errorf("default sections = %v", def.Sections)
+	}
+	if _, err := s.PrimeProfile("missing"); err == nil {
+		t.Error("unknown profile should fail")
+	}
+
+	if err := s.SetConfigValue("prime.agent.sections", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetConfigValue("prime.agent.limit", ""); err != nil {
+		t.Fatal(err)
+	}
+	if names := s.PrimeProfileNames(); len(names) != 1 {
+		t.Errorf("clearing every field should remove the profile, got %v", names)
+	}
+}
+
+func TestRecordPrimePerActor(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+
+	if _, ok := s.LastPrime("alice"); ok {
+		t.Fatal("no prime recorded yet")
+	}
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := s.RecordPrime("alice", at); err != nil {
+		t.Fatalf("RecordPrime: %v", err)
+	}
+	if err := s.RecordPrime("bob", at.Add(time.Hour)); err != nil {
+		t.Fatalf("RecordPrime: %v", err)
+	}
+	if got, ok := s.LastPrime("alice"); !ok || !got.Equal(at) {
+		t.Errorf("alice last prime = %v, %v", got, ok)
+	}
+	if got, _ := s.LastPrime("bob"); !got.Equal(at.Add(time.Hour)) {
+		t.Errorf("bob last prime = %v", got)
+	}
+}
+
+func TestRulesSummary(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+	for _, kv := range [][2]string{
+		{"status.sequence", "open,in_progress,closed"},
+		{"status.fsm", "true"},
+		{"status.wip_limits", "in_progress:3"},
+	} {
+		if err := s.SetConfigValue(kv[0], kv[1]); err != nil {
+			t.Fatalf("set %s: %v", kv[0], err)
+		}
+	}
+	rules := strings.Join(s.RulesSummary(), "\n")
+	for _, want := range []string{
+		"open -> in_progress -> closed",
+		"At most 3 issues may be in_progress at once.",
+		"Closed issues can only be reopened",
+	} {
+		if !strings.Contains(rules, want) {
+			t.Errorf("rules missing %q:\n%s", want, rules)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/model"
+)
+
+// resetOnRecur lists the sections a new instance of a recurring issue starts
+// without: they record what happened to the previous instance.
+var resetOnRecur = map[string]bool{"Notes": true, "History": true, "Links": true, "Comments": true}
+
+// SetRecurrence sets or (with an empty spec) clears an issue's recurrence rule.
+func (s *Store) SetRecurrence(id, spec string) error {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(spec) == "" {
+		if issue.Recur == "" {
+			return nil
+		}
+		if err := s.vault.PropertyRemove(id, "recur"); err != nil {
+			return err
+		}
+		if err := s.touchUpdatedAt(id); err != nil {
+			return err
+		}
+		_ = s.appendHistory(id, fmt.Sprintf("recur: %s -> (none)", issue.Recur))
+		return nil
+	}
+
+	rec, err := dates.ParseRecurrence(spec)
+	if err != nil {
+		return validationError(err)
+	}
+	if err := s.vault.PropertySet(id, "recur", fmt.Sprintf("%q", rec.String())); err != nil {
+		return err
+	}
+	if err := s.touchUpdatedAt(id); err != nil {
+		return err
+	}
+	_ = s.appendHistory(id, fmt.Sprintf("recur: %s", rec.String()))
+	return nil
+}
+
+// RecurringIssues returns every non-closed issue carrying a recurrence rule,
+// soonest wake-up first; issues that are not deferred sort ahead (they are due).
+func (s *Store) RecurringIssues() ([]*model.Issue, error) {
+	all, err := s.ListIssues(FilterOptions{Status: "!closed"})
+	if err != nil {
+		return nil, err
+	}
+	var out []*model.Issue
+	for _, issue := range all {
+		if issue.Recur != "" {
+			out = append(out, issue)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].DeferUntil != out[j].DeferUntil {
+			return out[i].DeferUntil < out[j].DeferUntil
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// spawnNextInstance creates the successor of a just-closed recurring issue:
+// a fresh ID with the same title, type, priority, assignee, labels, parent,
+// estimate, and rule, deferred until the next occurrence after closedAt and
+// linked to its predecessor through follows/led_to. A predecessor with a due
+// date passes on its offset: the successor is due as long after waking as
+// the predecessor was after its own start. The body keeps the description,
+// design, and acceptance criteria (unchecked) and drops per-instance sections.
+// Returns nil when a later instance already exists (the issue was reopened
+// and closed again).
+func (s *Store) spawnNextInstance(prev *model.Issue, closedAt time.Time) (*model.Issue, error) {
+	rec, err := dates.ParseRecurrence(prev.Recur)
+	if err != nil {
+		return nil, err
+	}
+	for _, succID := range prev.LedTo {
+		if succ, err := s.ReadIssue(succID); err == nil && succ.Recur != "" && succ.Status != model.StatusClosed {
+			return nil, nil
+		}
+	}
+
+	until := rec.Next(closedAt)
+	untilStr := dates.Format(until)
+	due := ""
+	if offset, ok := s.dueOffset(prev); ok {
+		due = dates.Format(until.Add(offset))
+	}
+	next, err := s.CreateIssue(prev.Title, "", string(prev.Type), int(prev.Priority), prev.Assignee, prev.Labels, prev.Parent, CreateOptions{
+		Body:       recurringBody(prev.Body),
+		Recur:      rec.String(),
+		DeferUntil: untilStr,
+		Due:        due,
+		Estimate:   prev.Estimate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.AddFollows(next.ID, prev.ID); err != nil {
+		return next, err
+	}
+	_ = s.appendHistory(next.ID, fmt.Sprintf("recur: next instance after %s (%s), deferred until %s", prev.ID, rec.String(), untilStr))
+	_ = s.appendHistory(prev.ID, fmt.Sprintf("recur: scheduled next instance %s for %s", next.ID, untilStr))
+	return s.ReadIssue(next.ID)
+}
+
+// dueOffset is how long after its start prev was due, where the start is
+// the date a spawned instance was deferred until and the creation time of
+// the first instance. Date-only due dates count whole days. ok is false
+// when prev has no usable due date.
+func (s *Store) dueOffset(prev *model.Issue) (time.Duration, bool) {
+	if prev.Due == "" {
+		return 0, false
+	}
+	due, err := dates.Parse(prev.Due, prev.CreatedAt)
+	if err != nil {
+		return 0, false
+	}
+	start := prev.CreatedAt.UTC()
+	if at, ok := s.scheduledStart(prev); ok {
+		start = at
+	}
+	if dates.Format(due) == due.Format(dates.Layout) {
+		start = start.Truncate(24 * time.Hour)
+	}
+	return due.Sub(start), true
+}
+
+// scheduledStart returns the date a spawned instance was deferred until:
+// its defer_until while still deferred, otherwise the next occurrence after
+// its predecessor closed, worked out again since waking clears defer_until.
+func (s *Store) scheduledStart(issue *model.Issue) (time.Time, bool) {
+	if issue.DeferUntil != "" {
+		t, err := dates.Parse(issue.DeferUntil, issue.CreatedAt)
+		return t, err == nil
+	}
+	for _, predID := range issue.Follows {
+		pred, err := s.ReadIssue(predID)
+		if err != nil || pred.Recur == "" || !contains(pred.LedTo, issue.ID) {
+			continue
+		}
+		rec, err := dates.ParseRecurrence(pred.Recur)
+		closedAt, cerr := time.Parse(time.RFC3339, pred.ClosedAt)
+		if err != nil || cerr != nil {
+			continue
+		}
+		return rec.Next(closedAt), true
+	}
+	return time.Time{}, false
+}
+
+// recurringBody copies an issue body for the next instance: per-instance
+// sections are emptied and acceptance-criteria checkboxes are unchecked.
+func recurringBody(body string) string {
+	var out []string
+	section := ""
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			section = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			out = append(out, line)
+			if resetOnRecur[section] {
+				out = append(out, "", "")
+			}
+			continue
+		}
+		if resetOnRecur[section] {
+			continue
+		}
+		if section == "Acceptance Criteria" && len(model.ParseChecklist(line)) == 1 {
+			line = model.SetCheckbox(line, false)
+		}
+		out = append(out, line)
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RamXX/nd/internal/dates"
+	"github.com/RamXX/nd/internal/model"
+)
+
+func TestCloseRecurringSpawnsNextInstance(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	prev, _ := s.CreateIssue("Audit dependencies", "Run the audit", "chore", 1, "alice", []string{"ops"}, "")
+	if err := s.SetRecurrence(prev.ID, "every 2w"); err != nil {
+		t.Fatalf("SetRecurrence: %v", err)
+	}
+	if err := s.UpdateBody(prev.ID, strings.Replace(mustRead(t, s, prev.ID).Body,
+		"## Acceptance Criteria\n", "## Acceptance Criteria\n- [x] report filed\n", 1)); err != nil {
+		t.Fatal(err)
+	}
+	_ = s.AppendNotes(prev.ID, "found two stale packages")
+
+	if err := s.CloseIssue(prev.ID, "done"); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+	closed := mustRead(t, s, prev.ID)
+	if len(closed.LedTo) != 1 {
+		t.Fatalf("closed instance should lead to the next one, led_to = %v", closed.LedTo)
+	}
+	next := mustRead(t, s, closed.LedTo[0])
+
+	want, _ := dates.Resolve("+2w", time.Now())
+	if next.Status != model.StatusDeferred || next.DeferUntil != want {
+		t.Errorf("next instance: status %s until %q, want deferred until %q", next.Status, next.DeferUntil, want)
+	}
+	if next.Title != prev.Title || next.Assignee != "alice" || next.Priority != 1 || next.Recur != "2w" ||
+		len(next.Labels) != 1 || next.Labels[0] != "ops" || len(next.Follows) != 1 || next.Follows[0] != prev.ID {
+		t.Errorf("next instance did not inherit its predecessor: %+v", next)
+	}
+	if !strings.Contains(next.Body, "Run the audit") || !strings.Contains(next.Body, "- [ ] report filed") {
+		t.Errorf("next body should keep description and unchecked criteria:\n%s", next.Body)
+	}
+	if strings.Contains(next.Body, "stale packages") || strings.Contains(next.Body, "status: open -> closed") {
+		t.Errorf("next body should not carry notes or history:\n%s", next.Body)
+	}
+
+	// Reopening and closing again must not spawn a second successor.
+	if err := s.ReopenIssue(prev.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CloseIssue(prev.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustRead(t, s, prev.ID).LedTo; len(got) != 1 {
+		t.Errorf("re-closing spawned another instance: led_to = %v", got)
+	}
+	recurring, _ := s.RecurringIssues()
+	if len(recurring) != 1 || recurring[0].ID != next.ID {
+		t.Errorf("RecurringIssues = %v, want only %s", recurring, next.ID)
+	}
+}
+
+func TestRecurringInstanceKeepsDueOffset(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("due.sla", "P2:1d"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+	due, _ := dates.Resolve("+3d", time.Now())
+	first, _ := s.CreateIssue("Report", "", "task", 2, "", nil, "", CreateOptions{Due: due, Recur: "1w"})
+	if err := s.CloseIssue(first.ID, "done"); err != nil {
+		t.Fatalf("CloseIssue: %v", err)
+	}
+	second := mustRead(t, s, mustRead(t, s, first.ID).LedTo[0])
+	want, _ := dates.Resolve("+10d", time.Now())
+	if second.Due != want {
+		t.Errorf("second instance due = %q, want %q (3 days after waking on %s)", second.Due, want, second.DeferUntil)
+	}
+
+	// The offset survives waking, which clears defer_until.
+	if err := s.UnDeferIssue(second.ID); err != nil {
+		t.Fatalf("UnDeferIssue: %v", err)
+	}
+	if err := s.CloseIssue(second.ID, "done"); err != nil {
+		t.Fatalf("close second: %v", err)
+	}
+	third := mustRead(t, s, mustRead(t, s, second.ID).LedTo[0])
+	until, _ := time.Parse(dates.Layout, third.DeferUntil)
+	if want := dates.Format(until.AddDate(0, 0, 3)); third.Due != want {
+		t.Errorf("third instance due = %q, want %q", third.Due, want)
+	}
+}
+
+func TestSetRecurrenceValidates(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, _ := s.CreateIssue("Rotate keys", "", "chore", 2, "", nil, "")
+	if err := s.SetRecurrence(issue.ID, "fortnightly"); !errors.Is(err, ErrValidation) {
+		t.Errorf("unknown rule: err = %v, want %v", err, ErrValidation)
+	}
+	if err := s.SetRecurrence(issue.ID, "0 9 1 * *"); err != nil {
+		t.Fatalf("cron rule: %v", err)
+	}
+	if got := mustRead(t, s, issue.ID).Recur; got != "0 9 1 * *" {
+		t.Errorf("Recur = %q", got)
+	}
+	if err := s.SetRecurrence(issue.ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustRead(t, s, issue.ID).Recur; got != "" {
+		t.Errorf("cleared Recur = %q", got)
+	}
+}
+
+func mustRead(t *testing.T, s *Store, id string) *model.Issue {
+	t.Helper()
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		t.Fatalf("ReadIssue %s: %v", id, err)
+	}
+	return issue
+}
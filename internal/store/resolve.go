@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AmbiguousIDError reports short ID input that matches several issues.
+type AmbiguousIDError struct {
+	Input      string
+	Candidates []string // sorted
+}
+
+func (e *AmbiguousIDError) Error() string {
+	return fmt.Sprintf("%q matches %d issues: %s; use more of the ID", e.Input, len(e.Candidates), strings.Join(e.Candidates, ", "))
+}
+
+func (e *AmbiguousIDError) Is(target error) bool { return target == ErrValidation }
+
+// ResolveID maps user input to an existing issue ID. Besides the full ID it
+// accepts, in order of preference: the full ID in any case; the ID without
+// the vault prefix (a3f8, a3f8.1); and a unique leading part of the hash
+// (a3f for PROJ-a3f8). Dot-notation child suffixes must match exactly, so
+// a3f never matches PROJ-a3f8.1. Input that matches several issues returns an
+// AmbiguousIDError; input that matches none returns a NotFoundError.
+func (s *Store) ResolveID(input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", validationError(fmt.Errorf("empty issue ID"))
+	}
+	if s.IssueExists(input) {
+		return input, nil
+	}
+
+	ids := s.issueIDs()
+	want := strings.ToLower(input)
+	prefix := strings.ToLower(s.Prefix()) + "-"
+	hash := strings.TrimPrefix(want, prefix)
+
+	matches := matchIDs(ids, func(id string) bool { return id == want })
+	if len(matches) == 0 {
+		matches = matchIDs(ids, func(id string) bool { return id == prefix+want })
+	}
+	if len(matches) == 0 && !strings.Contains(hash, "-") {
+		base, children, _ := strings.Cut(hash, ".")
+		matches = matchIDs(ids, func(id string) bool {
+			idBase, idChildren, _ := strings.Cut(strings.TrimPrefix(id, prefix), ".")
+			return base != "" && strings.HasPrefix(id, prefix) && idChildren == children && strings.HasPrefix(idBase, base)
+		})
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", s.NotFound(input)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", &AmbiguousIDError{Input: input, Candidates: matches}
+	}
+}
+
+// ResolveIDs resolves each of inputs with ResolveID, stopping at the first
+// error.
+func (s *Store) ResolveIDs(inputs []string) ([]string, error) {
+	out := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		id, err := s.ResolveID(in)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// matchIDs returns the IDs whose lowercase form satisfies match, sorted.
+func matchIDs(ids []string, match func(lower string) bool) []string {
+	var out []string
+	for _, id := range ids {
+		if match(strings.ToLower(id)) {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveID(t *testing.T) {
+	s, err := Init(t.TempDir(), "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer s.Close()
+	for _, id := range []string{"TST-a3f8", "TST-a3f8.1", "TST-a3c1", "TST-b200"} {
+		if _, err := s.CreateIssueWithID(id, "Issue "+id, "", "task", 2, "", nil, ""); err != nil {
+			t.Fatalf("create %s: %v", id, err)
+		}
+	}
+
+	tests := []struct{ input, want string }{
+		{"TST-a3f8", "TST-a3f8"},
+		{"tst-A3F8", "TST-a3f8"},
+		{"a3f8", "TST-a3f8"},
+		{"a3f8.1", "TST-a3f8.1"},
+		{"TST-a3f8.1", "TST-a3f8.1"},
+		{"a3f", "TST-a3f8"}, // children need their .N suffix
+		{"b", "TST-b200"},
+		{" tst-b2 ", "TST-b200"},
+	}
+	for _, tt := range tests {
+		got, err := s.ResolveID(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveID(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+		}
+	}
+
+	_, err = s.ResolveID("a3")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("ResolveID(a3) = %v, want an ambiguity error", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0] != "TST-a3c1" || ambiguous.Candidates[1] != "TST-a3f8" {
+		t.Errorf("candidates = %v", ambiguous.Candidates)
+	}
+
+	for _, input := range []string{"c", "OTHER-a3f8", "a3f8.2", ""} {
+		if _, err := s.ResolveID(input); err == nil {
+			t.Errorf("ResolveID(%q) should fail", input)
+		}
+	}
+	if _, err := s.ResolveID("zzzz"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ResolveID(zzzz) = %v, want ErrNotFound", err)
+	}
+}
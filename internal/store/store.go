@@ -27,6 +27,7 @@ func gitignoreEntries(trackIssues bool) []string {
 	}
 	return append(entries,
 		".vlt.lock",
+		primeStateFile,
 		".trash/",
 		".guard/",
 		".piv-loop-state.json",
@@ -99,6 +100,31 @@ type Config struct {
 	StatusSequence  string `yaml:"status_sequence,omitempty"`
 	StatusFSM       bool   `yaml:"status_fsm,omitempty"`
 	StatusExitRules string `yaml:"status_exit_rules,omitempty"`
+	StatusACGate    string `yaml:"status_ac_gate,omitempty"`
+	StatusGuards    string `yaml:"status_guards,omitempty"`
+
+	StatusWIPLimits      string `yaml:"status_wip_limits,omitempty"`
+	StatusWIPPerAssignee string `yaml:"status_wip_per_assignee,omitempty"`
+
+	// DueSLA sets the default due date of new issues per priority.
+	// Format: "P0:1d,P1:3d,P2:2w".
+	DueSLA string `yaml:"due_sla,omitempty"`
+
+	// Workflows overrides sequence and exit rules per issue type or
+	// "label:<name>". See WorkflowFor.
+	Workflows map[string]WorkflowConfig `yaml:"workflows,omitempty"`
+
+	// GuardAllowVaultWrites lets nd guard pass direct writes to issue
+	// files, for vaults where humans edit them by hand.
+	GuardAllowVaultWrites bool `yaml:"guard_allow_vault_writes,omitempty"`
+
+	// Prime holds named nd prime profiles. See PrimeProfile.
+	Prime map[string]PrimeProfile `yaml:"prime,omitempty"`
+
+	// Workflow is the structured replacement for the status_* keys and
+	// Workflows above. When set, those fields are derived from it on load
+	// and folded back into it on save.
+	Workflow *WorkflowSchema `yaml:"workflow,omitempty"`
 }
 
 type InitOptions struct {
@@ -120,7 +146,7 @@ type Store struct {
 func Open(dir string) (*Store, error) {
 	unlock, err := vlt.LockVault(dir, true)
 	if err != nil {
-		return nil, fmt.Errorf("lock vault: %w", err)
+		return nil, lockedError(fmt.Errorf("lock vault: %w", err))
 	}
 
 	v, err := vlt.Open(dir)
@@ -188,11 +214,33 @@ func Init(dir, prefix, author string, opts ...InitOptions) (*Store, error) {
 }
 
 func (s *Store) loadConfig() error {
-	data, err := os.ReadFile(filepath.Join(s.dir, ".nd.yaml"))
+	cfg, err := ReadConfig(s.dir)
+	if err != nil {
+		return err
+	}
+	s.config = cfg
+	return nil
+}
+
+// ReadConfig reads and validates the configuration of the vault at dir
+// without taking the vault lock, for read-only callers such as nd guard
+// that must not wait on other nd processes.
+func ReadConfig(dir string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(filepath.Join(dir, ".nd.yaml"))
 	if err != nil {
-		return fmt.Errorf("read .nd.yaml: %w", err)
+		return cfg, fmt.Errorf("read .nd.yaml: %w", err)
+	}
+	if problems := validateConfigData(data); len(problems) > 0 {
+		return cfg, configProblemsError(problems)
 	}
-	return yaml.Unmarshal(data, &s.config)
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	if cfg.Workflow != nil {
+		applyWorkflowSchema(&cfg)
+	}
+	return cfg, nil
 }
 
 // Vault returns the underlying vlt.Vault for direct operations.
@@ -216,7 +264,12 @@ func (s *Store) IssueExists(id string) bool {
 
 // CustomStatuses parses the status_custom config field into a slice of model.Status.
 func (s *Store) CustomStatuses() []model.Status {
-	return parseCSVStatuses(s.config.StatusCustom)
+	return s.config.CustomStatuses()
+}
+
+// CustomStatuses parses the status_custom field.
+func (c Config) CustomStatuses() []model.Status {
+	return parseCSVStatuses(c.StatusCustom)
 }
 
 // StatusSequence parses the status_sequence config field into an ordered slice.
@@ -271,10 +324,14 @@ func parseCSVStatuses(csv string) []model.Status {
 }
 
 // SaveConfig writes the current config back to .nd.yaml.
+// Vaults on the structured workflow: schema are written back in that form.
 func (s *Store) SaveConfig() error {
-	data, err := yaml.Marshal(s.config)
+	data, err := marshalConfig(s.config)
 	if err != nil {
-		return fmt.Errorf("marshal config: %w", err)
+		return err
+	}
+	if s.config.Workflow != nil {
+		s.config.Workflow = buildWorkflowSchema(s.config, s.config.Workflow)
 	}
 	return os.WriteFile(filepath.Join(s.dir, ".nd.yaml"), data, 0o644)
 }
@@ -282,58 +339,32 @@ func (s *Store) SaveConfig() error {
 var validConfigKeyRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
 
 // SetConfigValue sets a config field by dot-notation key with validation.
+// Rejected keys and values are ErrValidation errors.
 func (s *Store) SetConfigValue(key, value string) error {
+	if err := s.setConfigValue(key, value); err != nil {
+		return validationError(err)
+	}
+	return nil
+}
+
+func (s *Store) setConfigValue(key, value string) error {
 	switch key {
 	case "status.custom":
-		if value != "" {
-			for _, name := range strings.Split(value, ",") {
-				name = strings.TrimSpace(strings.ToLower(name))
-				if name == "" {
-					continue
-				}
-				if !validConfigKeyRe.MatchString(name) {
-					return fmt.Errorf("invalid custom status name %q: must be lowercase alphanumeric/underscore", name)
-				}
-				if model.IsBuiltinStatus(name) {
-					return fmt.Errorf("custom status %q collides with built-in status", name)
-				}
-			}
+		if err := validateCustomValue(value); err != nil {
+			return err
 		}
 		s.config.StatusCustom = value
 
 	case "status.sequence":
-		if value != "" {
-			custom := s.CustomStatuses()
-			seen := make(map[string]bool)
-			for _, name := range strings.Split(value, ",") {
-				name = strings.TrimSpace(strings.ToLower(name))
-				if name == "" {
-					continue
-				}
-				if seen[name] {
-					return fmt.Errorf("duplicate status %q in sequence", name)
-				}
-				seen[name] = true
-				if !model.IsBuiltinStatus(name) {
-					found := false
-					for _, c := range custom {
-						if string(c) == name {
-							found = true
-							break
-						}
-					}
-					if !found {
-						return fmt.Errorf("status %q in sequence is not a built-in or custom status", name)
-					}
-				}
-			}
+		if err := validateSequenceValue(value, s.CustomStatuses()); err != nil {
+			return err
 		}
 		s.config.StatusSequence = value
 
 	case "status.fsm":
 		switch strings.ToLower(value) {
 		case "true", "1", "yes":
-			if s.config.StatusSequence == "" {
+			if s.config.StatusSequence == "" && !s.hasWorkflowSequence() {
 				return fmt.Errorf("cannot enable FSM without a status sequence; set status.sequence first")
 			}
 			s.config.StatusFSM = true
@@ -344,41 +375,182 @@ func (s *Store) SetConfigValue(key, value string) error {
 		}
 
 	case "status.exit_rules":
+		if err := validateExitRulesValue(value, s.CustomStatuses()); err != nil {
+			return err
+		}
+		s.config.StatusExitRules = value
+
+	case "status.ac_gate":
+		if err := validateACGateValue(value, s.CustomStatuses()); err != nil {
+			return err
+		}
+		s.config.StatusACGate = value
+
+	case "status.guards":
 		if value != "" {
-			custom := s.CustomStatuses()
-			for _, rule := range strings.Split(value, ";") {
-				rule = strings.TrimSpace(rule)
-				if rule == "" {
-					continue
-				}
-				parts := strings.SplitN(rule, ":", 2)
-				if len(parts) != 2 {
-					return fmt.Errorf("invalid exit rule %q: expected format status:target1,target2", rule)
-				}
-				fromName := strings.TrimSpace(strings.ToLower(parts[0]))
-				if _, err := model.ParseStatusWithCustom(fromName, custom); err != nil {
-					return fmt.Errorf("invalid status %q in exit rule: %w", fromName, err)
-				}
-				for _, target := range strings.Split(parts[1], ",") {
-					target = strings.TrimSpace(strings.ToLower(target))
-					if target == "" {
-						continue
-					}
-					if _, err := model.ParseStatusWithCustom(target, custom); err != nil {
-						return fmt.Errorf("invalid target %q in exit rule for %s: %w", target, fromName, err)
-					}
-				}
+			if err := validateGuardsValue(value, s.CustomStatuses()); err != nil {
+				return err
 			}
 		}
-		s.config.StatusExitRules = value
+		s.config.StatusGuards = value
+
+	case "status.wip_limits":
+		if err := validateWIPValue(value, s.CustomStatuses()); err != nil {
+			return err
+		}
+		s.config.StatusWIPLimits = value
+
+	case "status.wip_per_assignee":
+		if err := validateWIPValue(value, s.CustomStatuses()); err != nil {
+			return err
+		}
+		s.config.StatusWIPPerAssignee = value
+
+	case "due.sla":
+		if err := validateSLAValue(value); err != nil {
+			return err
+		}
+		s.config.DueSLA = value
+
+	case "guard.allow_vault_writes":
+		switch strings.ToLower(value) {
+		case "true", "1", "yes":
+			s.config.GuardAllowVaultWrites = true
+		case "false", "0", "no", "":
+			s.config.GuardAllowVaultWrites = false
+		default:
+			return fmt.Errorf("invalid boolean value %q for guard.allow_vault_writes", value)
+		}
 
 	default:
+		if strings.HasPrefix(key, "workflow.") {
+			if err := s.setWorkflowValue(key, value); err != nil {
+				return err
+			}
+			break
+		}
+		if strings.HasPrefix(key, "prime.") {
+			if err := s.setPrimeValue(key, value); err != nil {
+				return err
+			}
+			break
+		}
 		return fmt.Errorf("unknown config key %q", key)
 	}
 
 	return s.SaveConfig()
 }
 
+// validateCustomValue checks a status.custom value: lowercase names that do
+// not collide with built-in statuses.
+func validateCustomValue(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if !validConfigKeyRe.MatchString(name) {
+			return fmt.Errorf("invalid custom status name %q: must be lowercase alphanumeric/underscore", name)
+		}
+		if model.IsBuiltinStatus(name) {
+			return fmt.Errorf("custom status %q collides with built-in status", name)
+		}
+	}
+	return nil
+}
+
+// validateACGateValue checks a status.ac_gate value ("closed,review->qa").
+func validateACGateValue(value string, custom []model.Status) error {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(strings.ToLower(entry))
+		if entry == "" {
+			continue
+		}
+		names := []string{entry}
+		if from, to, ok := strings.Cut(entry, "->"); ok {
+			names = []string{strings.TrimSpace(from), strings.TrimSpace(to)}
+		}
+		for _, name := range names {
+			if _, err := model.ParseStatusWithCustom(name, custom); err != nil {
+				return fmt.Errorf("invalid status %q in ac gate: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSequenceValue checks a status.sequence value: every status must be
+// built-in or custom, with no duplicates.
+func validateSequenceValue(value string, custom []model.Status) error {
+	if value == "" {
+		return nil
+	}
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate status %q in sequence", name)
+		}
+		seen[name] = true
+		if !model.IsBuiltinStatus(name) {
+			found := false
+			for _, c := range custom {
+				if string(c) == name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("status %q in sequence is not a built-in or custom status", name)
+			}
+		}
+	}
+	return nil
+}
+
+// validateExitRulesValue checks a status.exit_rules value.
+func validateExitRulesValue(value string, custom []model.Status) error {
+	if value == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(value, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		parts := strings.SplitN(rule, ":", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid exit rule %q: expected format status:target1,target2", rule)
+		}
+		fromName := strings.TrimSpace(strings.ToLower(parts[0]))
+		if _, err := model.ParseStatusWithCustom(fromName, custom); err != nil {
+			return fmt.Errorf("invalid status %q in exit rule: %w", fromName, err)
+		}
+		for _, target := range strings.Split(parts[1], ",") {
+			target = strings.TrimSpace(strings.ToLower(target))
+			if target == "" {
+				continue
+			}
+			if _, err := model.ParseStatusWithCustom(target, custom); err != nil {
+				return fmt.Errorf("invalid target %q in exit rule for %s: %w", target, fromName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) hasWorkflowSequence() bool {
+	for _, wf := range s.config.Workflows {
+		if wf.Sequence != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // GetConfigValue returns the value of a config field by dot-notation key.
 func (s *Store) GetConfigValue(key string) (string, error) {
 	switch key {
@@ -399,7 +571,28 @@ func (s *Store) GetConfigValue(key string) (string, error) {
 		return "false", nil
 	case "status.exit_rules":
 		return s.config.StatusExitRules, nil
+	case "status.ac_gate":
+		return s.config.StatusACGate, nil
+	case "status.guards":
+		return s.config.StatusGuards, nil
+	case "status.wip_limits":
+		return s.config.StatusWIPLimits, nil
+	case "status.wip_per_assignee":
+		return s.config.StatusWIPPerAssignee, nil
+	case "due.sla":
+		return s.config.DueSLA, nil
+	case "guard.allow_vault_writes":
+		if s.config.GuardAllowVaultWrites {
+			return "true", nil
+		}
+		return "false", nil
 	default:
+		if strings.HasPrefix(key, "workflow.") {
+			return s.getWorkflowValue(key)
+		}
+		if strings.HasPrefix(key, "prime.") {
+			return s.getPrimeValue(key)
+		}
 		return "", fmt.Errorf("unknown config key %q", key)
 	}
 }
@@ -410,7 +603,11 @@ func (s *Store) ConfigEntries() [][2]string {
 	if s.config.StatusFSM {
 		fsm = "true"
 	}
-	return [][2]string{
+	vaultWrites := "false"
+	if s.config.GuardAllowVaultWrites {
+		vaultWrites = "true"
+	}
+	entries := [][2]string{
 		{"version", s.config.Version},
 		{"prefix", s.config.Prefix},
 		{"created_by", s.config.CreatedBy},
@@ -418,5 +615,24 @@ func (s *Store) ConfigEntries() [][2]string {
 		{"status.sequence", s.config.StatusSequence},
 		{"status.fsm", fsm},
 		{"status.exit_rules", s.config.StatusExitRules},
+		{"status.ac_gate", s.config.StatusACGate},
+		{"status.guards", s.config.StatusGuards},
+		{"status.wip_limits", s.config.StatusWIPLimits},
+		{"status.wip_per_assignee", s.config.StatusWIPPerAssignee},
+		{"due.sla", s.config.DueSLA},
+		{"guard.allow_vault_writes", vaultWrites},
+	}
+	for _, name := range s.WorkflowNames()[1:] {
+		wf := s.config.Workflows[name]
+		if wf.Sequence != "" {
+			entries = append(entries, [2]string{"workflow." + name + ".sequence", wf.Sequence})
+		}
+		if wf.ExitRules != "" {
+			entries = append(entries, [2]string{"workflow." + name + ".exit_rules", wf.ExitRules})
+		}
+	}
+	for _, name := range s.PrimeProfileNames() {
+		entries = append(entries, primeEntries(name, s.config.Prime[name])...)
 	}
+	return entries
 }
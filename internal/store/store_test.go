@@ -710,6 +710,24 @@ func TestFSMForwardSkip(t *testing.T) {
 	}
 }
 
+func TestCheckStatusDoesNotWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := setupFSMStore(t, dir)
+
+	issue, _ := s.CreateIssue("Test", "", "task", 2, "", nil, "")
+
+	if err := s.CheckStatus(issue.ID, "delivered"); err == nil {
+		t.Error("open -> delivered should fail the check (skips in_progress)")
+	}
+	if err := s.CheckStatus(issue.ID, "in_progress"); err != nil {
+		t.Errorf("open -> in_progress should pass the check: %v", err)
+	}
+	got, _ := s.ReadIssue(issue.ID)
+	if got.Status != model.StatusOpen {
+		t.Errorf("status = %s after CheckStatus, want open", got.Status)
+	}
+}
+
 func TestFSMBackward(t *testing.T) {
 	dir := t.TempDir()
 	s := setupFSMStore(t, dir)
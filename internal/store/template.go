@@ -0,0 +1,239 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/RamXX/nd/internal/model"
+	"github.com/RamXX/vlt"
+	"gopkg.in/yaml.v3"
+)
+
+// templatesDir is the vault subdirectory that holds issue body templates.
+const templatesDir = "templates"
+
+// mechanicalSections are maintained by nd itself (history, links, comments)
+// and are appended to every templated body that does not declare them.
+var mechanicalSections = []string{"History", "Links", "Comments"}
+
+var validTemplateNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Template is an issue body template loaded from <vault>/templates/<name>.md.
+// Frontmatter fields provide defaults for new issues; the body is rendered
+// with {{placeholder}} substitution.
+type Template struct {
+	Name             string   `yaml:"-"`
+	Type             string   `yaml:"type,omitempty"`
+	Priority         *int     `yaml:"priority,omitempty"`
+	Assignee         string   `yaml:"assignee,omitempty"`
+	Labels           []string `yaml:"labels,omitempty"`
+	RequiredSections []string `yaml:"required_sections,omitempty"`
+	Body             string   `yaml:"-"`
+}
+
+// CreateOptions carries optional inputs for CreateIssue and CreateIssueWithID.
+type CreateOptions struct {
+	Template   *Template // render the body from this template instead of the default sections
+	Body       string    // use this body verbatim (wins over Template)
+	Recur      string    // recurrence rule, already validated
+	DeferUntil string    // create the issue deferred until this date
+	Due        string    // due date, already resolved; empty applies the priority's SLA
+	Estimate   string    // effort estimate, already normalized (see model.FormatEffort)
+	PlanKey    string    // nd apply plan key
+}
+
+// LoadTemplate reads the named template from the vault's templates directory.
+// Returns an error wrapping os.ErrNotExist when the template does not exist.
+func (s *Store) LoadTemplate(name string) (*Template, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !validTemplateNameRe.MatchString(name) {
+		return nil, fmt.Errorf("invalid template name %q: must be lowercase alphanumeric, dash, or underscore", name)
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, templatesDir, name+".md"))
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	tmpl, err := parseTemplate(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	tmpl.Name = name
+	return tmpl, nil
+}
+
+// TemplateForType returns the template named after the issue type, or nil
+// when the vault has no such template.
+func (s *Store) TemplateForType(issueType model.IssueType) (*Template, error) {
+	tmpl, err := s.LoadTemplate(string(issueType))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return tmpl, err
+}
+
+// ListTemplates returns the names of all templates in the vault, sorted.
+func (s *Store) ListTemplates() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, templatesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".md"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func parseTemplate(content string) (*Template, error) {
+	var tmpl Template
+	yamlStr, bodyStart, found := vlt.ExtractFrontmatter(content)
+	if !found {
+		tmpl.Body = content
+		return &tmpl, nil
+	}
+	if err := yaml.Unmarshal([]byte(yamlStr), &tmpl); err != nil {
+		return nil, fmt.Errorf("unmarshal frontmatter: %w", err)
+	}
+	if tmpl.Priority != nil && (*tmpl.Priority < 0 || *tmpl.Priority > 4) {
+		return nil, fmt.Errorf("priority must be 0-4, got %d", *tmpl.Priority)
+	}
+	if tmpl.Type != "" {
+		if _, err := model.ParseIssueType(tmpl.Type); err != nil {
+			return nil, err
+		}
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if bodyStart < len(lines) {
+		tmpl.Body = strings.Join(lines[bodyStart:], "")
+	}
+	return &tmpl, nil
+}
+
+// Sections returns the required ## sections for issues created from this
+// template: the explicit required_sections list when set, otherwise every
+// level-2 heading in the template body.
+func (t *Template) Sections() []string {
+	if len(t.RequiredSections) > 0 {
+		return t.RequiredSections
+	}
+	return bodySections(t.Body)
+}
+
+// Render substitutes placeholders and returns an issue body that always
+// carries the sections nd maintains (History, Links, Comments).
+// Supported placeholders: {{id}}, {{title}}, {{type}}, {{priority}},
+// {{assignee}}, {{author}}, {{date}}, {{description}}.
+func (t *Template) Render(issue *model.Issue, description string) string {
+	body := t.Body
+	hasDescPlaceholder := strings.Contains(body, "{{description}}")
+
+	r := strings.NewReplacer(
+		"{{id}}", issue.ID,
+		"{{title}}", issue.Title,
+		"{{type}}", string(issue.Type),
+		"{{priority}}", issue.Priority.Short(),
+		"{{assignee}}", issue.Assignee,
+		"{{author}}", issue.CreatedBy,
+		"{{date}}", issue.CreatedAt.Format(time.DateOnly),
+		"{{description}}", description,
+	)
+	body = r.Replace(body)
+
+	if !hasDescPlaceholder && description != "" {
+		if idx := strings.Index(body, "## Description\n"); idx >= 0 {
+			at := idx + len("## Description\n")
+			body = body[:at] + description + "\n" + body[at:]
+		} else {
+			body = "## Description\n" + description + "\n\n" + body
+		}
+	}
+
+	body = strings.TrimRight(body, "\n") + "\n"
+	if !strings.HasPrefix(body, "\n") {
+		body = "\n" + body
+	}
+	present := make(map[string]bool)
+	for _, sec := range bodySections(body) {
+		present[sec] = true
+	}
+	for _, sec := range mechanicalSections {
+		if !present[sec] {
+			body += "\n## " + sec + "\n"
+			if sec != "Comments" {
+				body += "\n"
+			}
+		}
+	}
+	return body
+}
+
+// bodySections returns the names of all level-2 headings in a markdown body.
+func bodySections(body string) []string {
+	var sections []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "## ") {
+			sections = append(sections, strings.TrimSpace(strings.TrimPrefix(line, "## ")))
+		}
+	}
+	return sections
+}
+
+// MissingSections returns the required sections absent from an issue body.
+func MissingSections(body string, required []string) []string {
+	present := make(map[string]bool)
+	for _, sec := range bodySections(body) {
+		present[strings.ToLower(sec)] = true
+	}
+	var missing []string
+	for _, sec := range required {
+		if !present[strings.ToLower(sec)] {
+			missing = append(missing, sec)
+		}
+	}
+	return missing
+}
+
+// EnsureSections inserts empty ## sections for any names missing from the
+// issue body, placing them before ## History so nd-maintained sections stay last.
+func (s *Store) EnsureSections(id string, sections []string) error {
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	missing := MissingSections(issue.Body, sections)
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var insert strings.Builder
+	for _, sec := range missing {
+		insert.WriteString("\n## " + sec + "\n\n")
+	}
+
+	body := issue.Body
+	inserted := false
+	for _, anchor := range []string{"\n## History\n", "\n## Links\n", "\n## Comments\n"} {
+		if idx := strings.Index(body, anchor); idx >= 0 {
+			body = body[:idx] + insert.String() + body[idx:]
+			inserted = true
+			break
+		}
+	}
+	if !inserted {
+		body += insert.String()
+	}
+	return s.UpdateBody(id, body)
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const bugTemplate = `---
+type: bug
+priority: 1
+labels: [bug, triage]
+assignee: oncall
+---
+## Description
+{{description}}
+
+## Steps to Reproduce
+1.
+
+## Expected
+
+## Actual
+
+## Notes
+Reported for {{title}} ({{id}}) on {{date}}.
+`
+
+func writeTemplate(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, templatesDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, templatesDir, name+".md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeTemplate(t, dir, "bug", bugTemplate)
+
+	tmpl, err := s.LoadTemplate("bug")
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+	if tmpl.Type != "bug" || tmpl.Priority == nil || *tmpl.Priority != 1 || tmpl.Assignee != "oncall" {
+		t.Errorf("unexpected template defaults: %+v", tmpl)
+	}
+	if len(tmpl.Labels) != 2 || tmpl.Labels[0] != "bug" {
+		t.Errorf("labels = %v", tmpl.Labels)
+	}
+	want := []string{"Description", "Steps to Reproduce", "Expected", "Actual", "Notes"}
+	got := tmpl.Sections()
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Sections() = %v, want %v", got, want)
+	}
+
+	if _, err := s.LoadTemplate("../etc/passwd"); err == nil {
+		t.Error("path-like template names should be rejected")
+	}
+	if tmpl, err := s.TemplateForType("feature"); err != nil || tmpl != nil {
+		t.Errorf("TemplateForType without a file should be nil, got %v, %v", tmpl, err)
+	}
+}
+
+func TestCreateIssueFromTemplate(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeTemplate(t, dir, "bug", bugTemplate)
+	tmpl, err := s.LoadTemplate("bug")
+	if err != nil {
+		t.Fatalf("LoadTemplate: %v", err)
+	}
+
+	issue, err := s.CreateIssue("Login crash", "Crashes on submit", "bug", 1, "", nil, "", CreateOptions{Template: tmpl})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	read, err := s.ReadIssue(issue.ID)
+	if err != nil {
+		t.Fatalf("ReadIssue: %v", err)
+	}
+
+	for _, want := range []string{
+		"## Description\nCrashes on submit\n",
+		"## Steps to Reproduce\n",
+		"Reported for Login crash (" + issue.ID + ")",
+		"\n## History\n",
+		"\n## Links\n",
+		"\n## Comments\n",
+	} {
+		if !strings.Contains(read.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, read.Body)
+		}
+	}
+	if strings.Contains(read.Body, "{{") {
+		t.Errorf("unrendered placeholder in body:\n%s", read.Body)
+	}
+
+	// nd-maintained sections must keep working on templated bodies.
+	if err := s.UpdateStatus(issue.ID, "in_progress"); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+	read, _ = s.ReadIssue(issue.ID)
+	if !strings.Contains(read.Body, "status: open -> in_progress") {
+		t.Errorf("history not recorded on templated body:\n%s", read.Body)
+	}
+}
+
+func TestTemplateRenderWithoutDescriptionPlaceholder(t *testing.T) {
+	tmpl := &Template{Body: "## Context\n\n## Decision\n"}
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, err := s.CreateIssue("Pick a DB", "We need storage", "decision", 2, "", nil, "", CreateOptions{Template: tmpl})
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	if !strings.HasPrefix(issue.Body, "\n## Description\nWe need storage\n") {
+		t.Errorf("description should be prepended when template lacks it:\n%s", issue.Body)
+	}
+}
+
+func TestEnsureSections(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	issue, err := s.CreateIssue("Plain bug", "", "bug", 2, "", nil, "")
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+
+	required := []string{"Description", "Steps to Reproduce", "Expected"}
+	missing := MissingSections(issue.Body, required)
+	if len(missing) != 2 {
+		t.Fatalf("MissingSections = %v, want 2 entries", missing)
+	}
+
+	if err := s.EnsureSections(issue.ID, missing); err != nil {
+		t.Fatalf("EnsureSections: %v", err)
+	}
+	read, _ := s.ReadIssue(issue.ID)
+	if got := MissingSections(read.Body, required); len(got) != 0 {
+		t.Errorf("sections still missing after EnsureSections: %v", got)
+	}
+	if strings.Index(read.Body, "## Expected") > strings.Index(read.Body, "## History") {
+		t.Errorf("inserted sections should precede ## History:\n%s", read.Body)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/RamXX/nd/internal/dates"
 	"github.com/RamXX/nd/internal/enforce"
 	"github.com/RamXX/nd/internal/model"
 	"github.com/RamXX/vlt"
@@ -19,8 +20,18 @@ func (s *Store) UpdateField(id, field, value string) error {
 	return s.touchUpdatedAt(id)
 }
 
+// TransitionOptions adjusts the checks applied to a status transition.
+type TransitionOptions struct {
+	Force bool // override acceptance-criteria gates and WIP limits; the override is logged in history
+}
+
 // UpdateStatus changes the status of an issue with validation.
-func (s *Store) UpdateStatus(id string, newStatus model.Status) error {
+func (s *Store) UpdateStatus(id string, newStatus model.Status, opts ...TransitionOptions) error {
+	var topts TransitionOptions
+	if len(opts) > 0 {
+		topts = opts[0]
+	}
+
 	issue, err := s.ReadIssue(id)
 	if err != nil {
 		return err
@@ -28,15 +39,9 @@ func (s *Store) UpdateStatus(id string, newStatus model.Status) error {
 
 	oldStatus := issue.Status
 
-	// Validate transition.
-	if issue.Status == model.StatusClosed && newStatus != model.StatusOpen {
-		return fmt.Errorf("closed issues can only be reopened (set to open)")
-	}
-
-	if s.config.StatusFSM {
-		if err := s.validateFSMTransition(issue.Status, newStatus); err != nil {
-			return err
-		}
+	wipOverride, override, err := s.checkStatus(issue, newStatus, topts)
+	if err != nil {
+		return err
 	}
 
 	if err := s.vault.PropertySet(id, "status", string(newStatus)); err != nil {
@@ -46,7 +51,20 @@ func (s *Store) UpdateStatus(id string, newStatus model.Status) error {
 		return err
 	}
 
+	// A claim lease covers active work only; it ends when the issue moves on.
+	if issue.LeaseUntil != "" && newStatus != model.StatusInProgress {
+		if err := s.vault.PropertyRemove(id, "lease_until"); err != nil {
+			return err
+		}
+	}
+
 	_ = s.appendHistory(id, fmt.Sprintf("status: %s -> %s", oldStatus, newStatus))
+	if wipOverride != "" {
+		_ = s.appendHistory(id, wipOverride)
+	}
+	if override != "" {
+		_ = s.appendHistory(id, override)
+	}
 
 	if newStatus == model.StatusInProgress {
 		preds := s.detectPredecessors(issue)
@@ -60,21 +78,73 @@ func (s *Store) UpdateStatus(id string, newStatus model.Status) error {
 	return nil
 }
 
+// CheckStatus applies the checks UpdateStatus would make for moving id to
+// newStatus, without changing anything.
+func (s *Store) CheckStatus(id string, newStatus model.Status, opts ...TransitionOptions) error {
+	var topts TransitionOptions
+	if len(opts) > 0 {
+		topts = opts[0]
+	}
+	issue, err := s.ReadIssue(id)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.checkStatus(issue, newStatus, topts)
+	return err
+}
+
+// checkStatus validates a status change against the closed rule, the FSM,
+// guards, WIP limits, and the acceptance gate. It returns the history notes
+// for any forced WIP or acceptance override.
+func (s *Store) checkStatus(issue *model.Issue, newStatus model.Status, topts TransitionOptions) (wipOverride, acOverride string, err error) {
+	if issue.Status == model.StatusClosed && newStatus != model.StatusOpen {
+		return "", "", s.refuse(issue, newStatus, fmt.Errorf("closed issues can only be reopened (set to open)"))
+	}
+
+	if s.config.StatusFSM {
+		if err := s.validateFSMTransition(issue, newStatus); err != nil {
+			return "", "", err
+		}
+	}
+	if err := s.checkGuards(issue, newStatus, ""); err != nil {
+		return "", "", err
+	}
+	if wipOverride, err = s.checkWIP(issue, newStatus, topts.Force); err != nil {
+		return "", "", err
+	}
+	if acOverride, err = s.checkACGate(issue, newStatus, topts.Force); err != nil {
+		return "", "", err
+	}
+	return wipOverride, acOverride, nil
+}
+
 // CloseIssue closes an issue with an optional reason.
-func (s *Store) CloseIssue(id, reason string) error {
+func (s *Store) CloseIssue(id, reason string, opts ...TransitionOptions) error {
+	var topts TransitionOptions
+	if len(opts) > 0 {
+		topts = opts[0]
+	}
+
 	issue, err := s.ReadIssue(id)
 	if err != nil {
 		return err
 	}
 	if issue.Status == model.StatusClosed {
-		return fmt.Errorf("issue %s is already closed", id)
+		return s.refuse(issue, model.StatusClosed, fmt.Errorf("issue %s is already closed", id))
 	}
 
 	if s.config.StatusFSM {
-		if err := s.validateFSMTransition(issue.Status, model.StatusClosed); err != nil {
+		if err := s.validateFSMTransition(issue, model.StatusClosed); err != nil {
 			return err
 		}
 	}
+	if err := s.checkGuards(issue, model.StatusClosed, reason); err != nil {
+		return err
+	}
+	override, err := s.checkACGate(issue, model.StatusClosed, topts.Force)
+	if err != nil {
+		return err
+	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
 	if err := s.vault.PropertySet(id, "status", "closed"); err != nil {
@@ -83,6 +153,11 @@ func (s *Store) CloseIssue(id, reason string) error {
 	if err := s.vault.PropertySet(id, "closed_at", now); err != nil {
 		return err
 	}
+	if issue.LeaseUntil != "" {
+		if err := s.vault.PropertyRemove(id, "lease_until"); err != nil {
+			return err
+		}
+	}
 	if reason != "" {
 		if err := s.vault.PropertySet(id, "close_reason", fmt.Sprintf("%q", reason)); err != nil {
 			return err
@@ -92,6 +167,15 @@ func (s *Store) CloseIssue(id, reason string) error {
 		return err
 	}
 	_ = s.appendHistory(id, fmt.Sprintf("status: %s -> closed", issue.Status))
+	if override != "" {
+		_ = s.appendHistory(id, override)
+	}
+	if issue.Recur != "" {
+		closedAt, _ := time.Parse(time.RFC3339, now)
+		if _, err := s.spawnNextInstance(issue, closedAt); err != nil {
+			return fmt.Errorf("closed %s, but scheduling its next instance failed: %w", id, err)
+		}
+	}
 	return nil
 }
 
@@ -102,7 +186,7 @@ func (s *Store) ReopenIssue(id string) error {
 		return err
 	}
 	if issue.Status != model.StatusClosed {
-		return fmt.Errorf("issue %s is not closed (status: %s)", id, issue.Status)
+		return s.refuse(issue, model.StatusOpen, fmt.Errorf("issue %s is not closed (status: %s)", id, issue.Status))
 	}
 
 	if err := s.vault.PropertySet(id, "status", "open"); err != nil {
@@ -232,19 +316,34 @@ func (s *Store) RefreshAfterEdit(id string) error {
 }
 
 // DeferIssue sets the issue status to deferred with an optional until date.
+// until accepts anything dates.Parse does (e.g. 2026-03-01 or +2w); relative
+// values are resolved now and stored as an absolute date.
 func (s *Store) DeferIssue(id, until string) error {
 	issue, err := s.ReadIssue(id)
 	if err != nil {
 		return err
 	}
+	if until != "" {
+		resolved, err := dates.Resolve(until, time.Now())
+		if err != nil {
+			return validationError(fmt.Errorf("invalid --until: %w", err))
+		}
+		until = resolved
+	}
 	if issue.Status == model.StatusClosed {
-		return fmt.Errorf("cannot defer closed issue %s", id)
+		return s.refuse(issue, model.StatusDeferred, fmt.Errorf("cannot defer closed issue %s", id))
 	}
 	if s.config.StatusFSM {
-		if err := s.validateFSMTransition(issue.Status, model.StatusDeferred); err != nil {
+		if err := s.validateFSMTransition(issue, model.StatusDeferred); err != nil {
 			return err
 		}
 	}
+	if err := s.checkGuards(issue, model.StatusDeferred, ""); err != nil {
+		return err
+	}
+	if _, err := s.checkWIP(issue, model.StatusDeferred, false); err != nil {
+		return err
+	}
 
 	if err := s.vault.PropertySet(id, "status", "deferred"); err != nil {
 		return err
@@ -268,14 +367,20 @@ func (s *Store) UnDeferIssue(id string) error {
 		return err
 	}
 	if issue.Status != model.StatusDeferred {
-		return fmt.Errorf("issue %s is not deferred (status: %s)", id, issue.Status)
+		return s.refuse(issue, s.resumeStatusFromDeferred(issue), fmt.Errorf("issue %s is not deferred (status: %s)", id, issue.Status))
 	}
-	targetStatus := s.resumeStatusFromDeferred()
+	targetStatus := s.resumeStatusFromDeferred(issue)
 	if s.config.StatusFSM {
-		if err := s.validateFSMTransition(issue.Status, targetStatus); err != nil {
+		if err := s.validateFSMTransition(issue, targetStatus); err != nil {
 			return err
 		}
 	}
+	if err := s.checkGuards(issue, targetStatus, ""); err != nil {
+		return err
+	}
+	if _, err := s.checkWIP(issue, targetStatus, false); err != nil {
+		return err
+	}
 
 	if err := s.vault.PropertySet(id, "status", string(targetStatus)); err != nil {
 		return err
@@ -288,20 +393,58 @@ func (s *Store) UnDeferIssue(id string) error {
 	return nil
 }
 
+// WakeDueIssues undefers every deferred issue whose defer_until has been
+// reached, recording the wake-up in history. Issues the FSM, a guard, or a
+// WIP limit refuses to resume stay deferred and are reported in failed.
+func (s *Store) WakeDueIssues(now time.Time) (woken []string, failed map[string]error, err error) {
+	deferred, err := s.ListIssues(FilterOptions{Status: string(model.StatusDeferred)})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, issue := range deferred {
+		if issue.DeferUntil == "" || !dates.Reached(issue.DeferUntil, now) {
+			continue
+		}
+		if err := s.UnDeferIssue(issue.ID); err != nil {
+			if failed == nil {
+				failed = make(map[string]error)
+			}
+			failed[issue.ID] = err
+			continue
+		}
+		_ = s.appendHistory(issue.ID, fmt.Sprintf("woke: defer_until %s reached", issue.DeferUntil))
+		woken = append(woken, issue.ID)
+	}
+	return woken, failed, nil
+}
+
 func (s *Store) touchUpdatedAt(id string) error {
 	now := time.Now().UTC().Format(time.RFC3339)
 	return s.vault.PropertySet(id, "updated_at", now)
 }
 
-// validateFSMTransition enforces the FSM transition rules.
+// validateFSMTransition enforces the FSM transition rules of the workflow
+// that governs the issue (see WorkflowFor).
 // The engine is generic -- all behavior is driven by configuration:
-//   - status.sequence: forward +1 only, backward any
-//   - status.exit_rules: restrict exits from specific statuses to listed targets
+//   - sequence: forward +1 only, backward any
+//   - exit_rules: restrict exits from specific statuses to listed targets
 //   - Off-sequence statuses are unrestricted (escape hatch)
-func (s *Store) validateFSMTransition(from, to model.Status) error {
+func (s *Store) validateFSMTransition(issue *model.Issue, to model.Status) error {
+	if err := checkTransition(s.WorkflowFor(issue), issue.Status, to); err != nil {
+		return s.refuse(issue, to, err)
+	}
+	return nil
+}
+
+// checkTransition applies a workflow's sequence and exit rules to one move.
+func checkTransition(wf Workflow, from, to model.Status) error {
+	prefix := "FSM"
+	if wf.Name != DefaultWorkflow {
+		prefix = fmt.Sprintf("FSM (%s workflow)", wf.Name)
+	}
+
 	// Check exit rules first -- these override sequence logic.
-	exitRules := s.ExitRules()
-	if allowed, ok := exitRules[from]; ok {
+	if allowed, ok := wf.ExitRules[from]; ok {
 		for _, a := range allowed {
 			if a == to {
 				return nil
@@ -311,11 +454,11 @@ func (s *Store) validateFSMTransition(from, to model.Status) error {
 		for i, a := range allowed {
 			targets[i] = string(a)
 		}
-		return fmt.Errorf("FSM: cannot transition from %s to %s; allowed targets: %s",
-			from, to, strings.Join(targets, ", "))
+		return fmt.Errorf("%s: cannot transition from %s to %s; allowed targets: %s",
+			prefix, from, to, strings.Join(targets, ", "))
 	}
 
-	seq := s.StatusSequence()
+	seq := wf.Sequence
 	if len(seq) == 0 {
 		return nil
 	}
@@ -327,7 +470,7 @@ func (s *Store) validateFSMTransition(from, to model.Status) error {
 	if fromIdx >= 0 && toIdx >= 0 {
 		if toIdx > fromIdx {
 			if toIdx != fromIdx+1 {
-				return fmt.Errorf("FSM: cannot skip from %s to %s; next step is %s", from, to, seq[fromIdx+1])
+				return fmt.Errorf("%s: cannot skip from %s to %s; next step is %s", prefix, from, to, seq[fromIdx+1])
 			}
 		}
 		return nil
@@ -346,8 +489,9 @@ func indexInSequence(seq []model.Status, st model.Status) int {
 	return -1
 }
 
-func (s *Store) resumeStatusFromDeferred() model.Status {
-	if targets, ok := s.ExitRules()[model.StatusDeferred]; ok && len(targets) > 0 {
+func (s *Store) resumeStatusFromDeferred(issue *model.Issue) model.Status {
+	wf := s.WorkflowFor(issue)
+	if targets, ok := wf.ExitRules[model.StatusDeferred]; ok && len(targets) > 0 {
 		for _, target := range targets {
 			if target == model.StatusOpen {
 				return model.StatusOpen
@@ -355,6 +499,17 @@ func (s *Store) resumeStatusFromDeferred() model.Status {
 		}
 		return targets[0]
 	}
+	return s.startStatus(issue)
+}
+
+// startStatus is where an issue enters its workflow: open, or the first
+// step of a workflow whose sequence has no open step (e.g. proposed ->
+// accepted), where open would be an off-sequence escape hatch.
+func (s *Store) startStatus(issue *model.Issue) model.Status {
+	wf := s.WorkflowFor(issue)
+	if len(wf.Sequence) > 0 && indexInSequence(wf.Sequence, model.StatusOpen) < 0 {
+		return wf.Sequence[0]
+	}
 	return model.StatusOpen
 }
 
@@ -394,7 +549,7 @@ func (s *Store) AppendHistoryEntry(id, entry string) error {
 // id follows predecessorID (predecessorID led to id).
 func (s *Store) AddFollows(id, predecessorID string) error {
 	if id == predecessorID {
-		return fmt.Errorf("an issue cannot follow itself")
+		return validationError(fmt.Errorf("an issue cannot follow itself"))
 	}
 
 	issue, err := s.ReadIssue(id)
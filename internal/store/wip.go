@@ -0,0 +1,201 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// WIPUsage is the occupancy of one WIP-limited slot: a status, optionally
+// narrowed to a single assignee.
+type WIPUsage struct {
+	Status   model.Status `json:"status"`
+	Assignee string       `json:"assignee,omitempty"` // empty for the status-wide limit
+	Limit    int          `json:"limit"`
+	IDs      []string     `json:"ids"` // issues currently occupying the slot
+}
+
+// Used returns the number of occupied slots.
+func (u WIPUsage) Used() int { return len(u.IDs) }
+
+// Remaining returns the number of free slots, never negative.
+func (u WIPUsage) Remaining() int {
+	if n := u.Limit - len(u.IDs); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// WIPLimits parses status_wip_limits into status -> maximum issues.
+// Format: "in_progress:5,review:3"
+func (s *Store) WIPLimits() map[model.Status]int {
+	return parseWIPLimits(s.config.StatusWIPLimits)
+}
+
+// AssigneeWIPLimits parses status_wip_per_assignee into status -> maximum
+// issues per assignee. Same format as WIPLimits.
+func (s *Store) AssigneeWIPLimits() map[model.Status]int {
+	return parseWIPLimits(s.config.StatusWIPPerAssignee)
+}
+
+func parseWIPLimits(raw string) map[model.Status]int {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	result := make(map[model.Status]int)
+	for _, entry := range strings.Split(raw, ",") {
+		name, limit, ok := strings.Cut(entry, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil || n <= 0 {
+			continue
+		}
+		result[model.Status(strings.TrimSpace(strings.ToLower(name)))] = n
+	}
+	return result
+}
+
+func formatWIPLimits(limits map[string]int) string {
+	var parts []string
+	for _, st := range sortedKeys(limits) {
+		parts = append(parts, fmt.Sprintf("%s:%d", st, limits[st]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// validateWIPValue checks a status.wip_limits or status.wip_per_assignee value.
+func validateWIPValue(value string, custom []model.Status) error {
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limit, ok := strings.Cut(entry, ":")
+		if !ok {
+			return fmt.Errorf("invalid WIP limit %q: expected format status:limit", entry)
+		}
+		if err := validateWIPEntry(strings.TrimSpace(strings.ToLower(name)), strings.TrimSpace(limit), custom); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateWIPEntry(name, limit string, custom []model.Status) error {
+	st, err := model.ParseStatusWithCustom(name, custom)
+	if err != nil {
+		return fmt.Errorf("invalid status %q in WIP limit: %w", name, err)
+	}
+	if st == model.StatusClosed {
+		return fmt.Errorf("closed cannot have a WIP limit")
+	}
+	if n, err := strconv.Atoi(limit); err != nil || n <= 0 {
+		return fmt.Errorf("WIP limit for %s must be a positive integer, got %q", name, limit)
+	}
+	return nil
+}
+
+// WIPCapacity reports usage for every status-wide limit and, for each
+// assignee holding work in a per-assignee-limited status, that assignee's
+// usage. Results are sorted by status, then assignee.
+func (s *Store) WIPCapacity(issues []*model.Issue) []WIPUsage {
+	var out []WIPUsage
+	for st, limit := range s.WIPLimits() {
+		out = append(out, WIPUsage{Status: st, Limit: limit, IDs: occupants(issues, st, "")})
+	}
+	for st, limit := range s.AssigneeWIPLimits() {
+		seen := make(map[string]bool)
+		for _, issue := range issues {
+			if issue.Status != st || issue.Assignee == "" || seen[issue.Assignee] {
+				continue
+			}
+			seen[issue.Assignee] = true
+			out = append(out, WIPUsage{Status: st, Assignee: issue.Assignee, Limit: limit, IDs: occupants(issues, st, issue.Assignee)})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Status != out[j].Status {
+			return out[i].Status < out[j].Status
+		}
+		return out[i].Assignee < out[j].Assignee
+	})
+	return out
+}
+
+// AssigneeCapacity reports an assignee's usage of every per-assignee limit,
+// including limits they have not started using yet.
+func (s *Store) AssigneeCapacity(issues []*model.Issue, assignee string) []WIPUsage {
+	var out []WIPUsage
+	for st, limit := range s.AssigneeWIPLimits() {
+		out = append(out, WIPUsage{Status: st, Assignee: assignee, Limit: limit, IDs: occupants(issues, st, assignee)})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Status < out[j].Status })
+	return out
+}
+
+func occupants(issues []*model.Issue, st model.Status, assignee string) []string {
+	var ids []string
+	for _, issue := range issues {
+		if issue.Status == st && (assignee == "" || issue.Assignee == assignee) {
+			ids = append(ids, issue.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// checkWIP refuses a move into a status whose WIP limit (status-wide or for
+// the issue's assignee) is already reached. With force, the move proceeds
+// and the returned note describes the override for the history log.
+func (s *Store) checkWIP(issue *model.Issue, to model.Status, force bool) (string, error) {
+	limit, hasLimit := s.WIPLimits()[to]
+	perAssignee, hasPerAssignee := s.AssigneeWIPLimits()[to]
+	if !hasLimit && (!hasPerAssignee || issue.Assignee == "") {
+		return "", nil
+	}
+
+	all, err := s.ListIssues(FilterOptions{})
+	if err != nil {
+		return "", fmt.Errorf("load issues for WIP limits: %w", err)
+	}
+	var others []*model.Issue
+	for _, other := range all {
+		if other.ID != issue.ID {
+			others = append(others, other)
+		}
+	}
+
+	var full []WIPUsage
+	if hasLimit {
+		if u := (WIPUsage{Status: to, Limit: limit, IDs: occupants(others, to, "")}); u.Used() >= u.Limit {
+			full = append(full, u)
+		}
+	}
+	if hasPerAssignee && issue.Assignee != "" {
+		if u := (WIPUsage{Status: to, Assignee: issue.Assignee, Limit: perAssignee, IDs: occupants(others, to, issue.Assignee)}); u.Used() >= u.Limit {
+			full = append(full, u)
+		}
+	}
+	if len(full) == 0 {
+		return "", nil
+	}
+
+	var msgs []string
+	for _, u := range full {
+		scope := string(u.Status)
+		if u.Assignee != "" {
+			scope += " for " + u.Assignee
+		}
+		msgs = append(msgs, fmt.Sprintf("%s is at %d/%d (%s)", scope, u.Used(), u.Limit, strings.Join(u.IDs, ", ")))
+	}
+	if force {
+		return "wip-limit overridden: " + strings.Join(msgs, "; "), nil
+	}
+	return "", s.refuse(issue, to, fmt.Errorf("WIP limit reached: %s; finish or move one of them first, or use --force", strings.Join(msgs, "; ")))
+}
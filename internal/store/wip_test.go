@@ -0,0 +1,110 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWIPLimitBlocksStart(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.wip_limits", "in_progress:2"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+
+	a, _ := s.CreateIssue("A", "", "task", 2, "", nil, "")
+	b, _ := s.CreateIssue("B", "", "task", 2, "", nil, "")
+	c, _ := s.CreateIssue("C", "", "task", 2, "", nil, "")
+	for _, id := range []string{a.ID, b.ID} {
+		if err := s.UpdateStatus(id, "in_progress"); err != nil {
+			t.Fatalf("start %s: %v", id, err)
+		}
+	}
+
+	err = s.UpdateStatus(c.ID, "in_progress")
+	if err == nil {
+		t.Fatal("third in_progress issue should exceed the limit")
+	}
+	for _, want := range []string{"2/2", a.ID, b.ID} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should mention %q: %v", want, err)
+		}
+	}
+
+	if err := s.UpdateStatus(c.ID, "in_progress", TransitionOptions{Force: true}); err != nil {
+		t.Fatalf("forced start: %v", err)
+	}
+	read, _ := s.ReadIssue(c.ID)
+	if !strings.Contains(read.Body, "wip-limit overridden") {
+		t.Errorf("override not logged:\n%s", read.Body)
+	}
+}
+
+func TestWIPPerAssignee(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.SetConfigValue("status.wip_per_assignee", "in_progress:1"); err != nil {
+		t.Fatalf("SetConfigValue: %v", err)
+	}
+
+	a, _ := s.CreateIssue("A", "", "task", 2, "alice", nil, "")
+	b, _ := s.CreateIssue("B", "", "task", 2, "alice", nil, "")
+	c, _ := s.CreateIssue("C", "", "task", 2, "bob", nil, "")
+	if err := s.UpdateStatus(a.ID, "in_progress"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateStatus(b.ID, "in_progress"); err == nil || !strings.Contains(err.Error(), "alice") {
+		t.Errorf("alice's second start should fail naming her, got %v", err)
+	}
+	if err := s.UpdateStatus(c.ID, "in_progress"); err != nil {
+		t.Errorf("bob has his own slot: %v", err)
+	}
+
+	all, _ := s.ListIssues(FilterOptions{})
+	usage := s.AssigneeCapacity(all, "alice")
+	if len(usage) != 1 || usage[0].Used() != 1 || usage[0].Remaining() != 0 {
+		t.Errorf("AssigneeCapacity(alice) = %+v", usage)
+	}
+	if got := s.WIPCapacity(all); len(got) != 2 || got[0].Assignee != "alice" || got[1].Assignee != "bob" {
+		t.Errorf("WIPCapacity = %+v", got)
+	}
+}
+
+func TestWIPConfigValidation(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Init(dir, "TST", "tester")
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, bad := range []string{"in_progress", "in_progress:0", "in_progress:x", "nowhere:2", "closed:3"} {
+		if err := s.SetConfigValue("status.wip_limits", bad); err == nil {
+			t.Errorf("status.wip_limits=%q should be rejected", bad)
+		}
+	}
+	if err := s.SetConfigValue("status.wip_limits", "in_progress:5, blocked:3"); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.WIPLimits(); got["in_progress"] != 5 || got["blocked"] != 3 {
+		t.Errorf("WIPLimits = %v", got)
+	}
+
+	// Limits survive migration to the structured schema.
+	if _, err := s.MigrateConfig(false); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	s2, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s2.Close()
+	if got := s2.WIPLimits(); got["in_progress"] != 5 {
+		t.Errorf("WIPLimits after migrate = %v", got)
+	}
+}
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+// DefaultWorkflow is the name of the vault-wide workflow built from
+// status.sequence and status.exit_rules.
+const DefaultWorkflow = "default"
+
+// WorkflowConfig is a per-type or per-label workflow override stored in
+// .nd.yaml under workflows. Empty fields inherit the vault-wide value.
+type WorkflowConfig struct {
+	Sequence  string `yaml:"sequence,omitempty"`
+	ExitRules string `yaml:"exit_rules,omitempty"`
+}
+
+// Workflow is the resolved set of FSM rules that applies to an issue.
+type Workflow struct {
+	Name      string                          `json:"name"` // "default", an issue type, or "label:<name>"
+	Sequence  []model.Status                  `json:"sequence"`
+	ExitRules map[model.Status][]model.Status `json:"exit_rules"`
+}
+
+// WorkflowNames returns the configured workflow keys, sorted, with the
+// default workflow first.
+func (s *Store) WorkflowNames() []string {
+	names := make([]string, 0, len(s.config.Workflows))
+	for name := range s.config.Workflows {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return append([]string{DefaultWorkflow}, names...)
+}
+
+// Workflow returns the resolved workflow for a key ("default", a type, or
+// "label:<name>"). Unset fields fall back to the vault-wide rules.
+func (s *Store) Workflow(name string) (Workflow, error) {
+	wf := Workflow{
+		Name:      DefaultWorkflow,
+		Sequence:  s.StatusSequence(),
+		ExitRules: s.ExitRules(),
+	}
+	if name == DefaultWorkflow {
+		return wf, nil
+	}
+	cfg, ok := s.config.Workflows[name]
+	if !ok {
+		return Workflow{}, fmt.Errorf("no workflow named %q", name)
+	}
+	wf.Name = name
+	if cfg.Sequence != "" {
+		wf.Sequence = parseCSVStatuses(cfg.Sequence)
+	}
+	if cfg.ExitRules != "" {
+		wf.ExitRules = parseExitRules(cfg.ExitRules)
+	}
+	return wf, nil
+}
+
+// WorkflowFor picks the workflow that governs an issue. A label workflow
+// wins over a type workflow (the first matching label in the issue's label
+// order), and the default workflow applies when neither is configured.
+func (s *Store) WorkflowFor(issue *model.Issue) Workflow {
+	for _, label := range issue.Labels {
+		key := "label:" + strings.ToLower(label)
+		if _, ok := s.config.Workflows[key]; ok {
+			wf, _ := s.Workflow(key)
+			return wf
+		}
+	}
+	if _, ok := s.config.Workflows[string(issue.Type)]; ok {
+		wf, _ := s.Workflow(string(issue.Type))
+		return wf
+	}
+	wf, _ := s.Workflow(DefaultWorkflow)
+	return wf
+}
+
+// ValidateWorkflows checks every workflow against the current custom
+// statuses and returns one error per problem, prefixed with the workflow name.
+func (s *Store) ValidateWorkflows() []error {
+	var errs []error
+	custom := s.CustomStatuses()
+	if err := validateSequenceValue(s.config.StatusSequence, custom); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", DefaultWorkflow, err))
+	}
+	if err := validateExitRulesValue(s.config.StatusExitRules, custom); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %w", DefaultWorkflow, err))
+	}
+	for _, name := range s.WorkflowNames()[1:] {
+		cfg := s.config.Workflows[name]
+		if err := validateWorkflowKey(name); err != nil {
+			errs = append(errs, err)
+		}
+		if err := validateSequenceValue(cfg.Sequence, custom); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		if err := validateExitRulesValue(cfg.ExitRules, custom); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// validateWorkflowKey accepts an issue type or "label:<name>".
+func validateWorkflowKey(key string) error {
+	if label, ok := strings.CutPrefix(key, "label:"); ok {
+		if label == "" {
+			return fmt.Errorf("invalid workflow %q: empty label", key)
+		}
+		return nil
+	}
+	if _, err := model.ParseIssueType(key); err != nil {
+		return fmt.Errorf("invalid workflow %q: must be an issue type or label:<name>", key)
+	}
+	return nil
+}
+
+// setWorkflowValue handles workflow.<key>.sequence and workflow.<key>.exit_rules.
+// Clearing both fields removes the workflow.
+func (s *Store) setWorkflowValue(key, value string) error {
+	rest := strings.TrimPrefix(key, "workflow.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return fmt.Errorf("invalid workflow key %q: expected workflow.<type|label:name>.<sequence|exit_rules>", key)
+	}
+	name, field := strings.ToLower(rest[:dot]), rest[dot+1:]
+	if err := validateWorkflowKey(name); err != nil {
+		return err
+	}
+
+	cfg := s.config.Workflows[name]
+	custom := s.CustomStatuses()
+	switch field {
+	case "sequence":
+		if err := validateSequenceValue(value, custom); err != nil {
+			return err
+		}
+		cfg.Sequence = value
+	case "exit_rules":
+		if err := validateExitRulesValue(value, custom); err != nil {
+			return err
+		}
+		cfg.ExitRules = value
+	default:
+		return fmt.Errorf("unknown workflow field %q: must be sequence or exit_rules", field)
+	}
+
+	if cfg == (WorkflowConfig{}) {
+		delete(s.config.Workflows, name)
+		return nil
+	}
+	if s.config.Workflows == nil {
+		s.config.Workflows = make(map[string]WorkflowConfig)
+	}
+	s.config.Workflows[name] = cfg
+	return nil
+}
+
+// getWorkflowValue returns workflow.<key>.<field>.
+func (s *Store) getWorkflowValue(key string) (string, error) {
+	rest := strings.TrimPrefix(key, "workflow.")
+	dot := strings.LastIndex(rest, ".")
+	if dot <= 0 {
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+	cfg := s.config.Workflows[strings.ToLower(rest[:dot])]
+	switch rest[dot+1:] {
+	case "sequence":
+		return cfg.Sequence, nil
+	case "exit_rules":
+		return cfg.ExitRules, nil
+	default:
+		return "", fmt.Errorf("unknown config key %q", key)
+	}
+}
+
+// Transition kinds reported by WorkflowTransitions.
+const (
+	TransitionForward = "forward"   // next step in the sequence
+	TransitionBack    = "back"      // earlier step in the sequence (rework)
+	TransitionExit    = "exit_rule" // listed in the source status's exit rule
+	TransitionReopen  = "reopen"    // closed -> open
+	TransitionEscape  = "escape"    // off-sequence, unrestricted
+)
+
+// Transition is one legal status change in a workflow.
+type Transition struct {
+	From    model.Status `json:"from"`
+	To      model.Status `json:"to"`
+	Kind    string       `json:"kind"`
+	Refused string       `json:"refused,omitempty"` // why the move is refused right now
+}
+
+// AllStatuses returns the built-in statuses followed by the custom ones.
+func (s *Store) AllStatuses() []model.Status {
+	var all []model.Status
+	for _, name := range model.BuiltinStatusNames() {
+		all = append(all, model.Status(name))
+	}
+	return append(all, s.CustomStatuses()...)
+}
+
+// WorkflowTransitions enumerates every legal transition in wf, applying the
+// same rules as UpdateStatus: closed issues can only be reopened, and with
+// status.fsm disabled every other move is allowed.
+func (s *Store) WorkflowTransitions(wf Workflow) []Transition {
+	var out []Transition
+	all := s.AllStatuses()
+	for _, from := range all {
+		for _, to := range all {
+			if from == to {
+				continue
+			}
+			if kind, ok := s.transitionKind(wf, from, to); ok {
+				out = append(out, Transition{From: from, To: to, Kind: kind})
+			}
+		}
+	}
+	return out
+}
+
+// AllowedTransitions splits the moves the issue's workflow permits, in the
+// order of AllStatuses, into those it may make right now and those a guard,
+// WIP limit, or acceptance gate refuses without --force. Refused moves carry
+// the refusal message.
+func (s *Store) AllowedTransitions(issue *model.Issue) (allowed, refused []Transition) {
+	for _, t := range s.workflowMoves(issue) {
+		if _, _, err := s.checkStatus(issue, t.To, TransitionOptions{}); err != nil {
+			t.Refused = err.Error()
+			refused = append(refused, t)
+			continue
+		}
+		allowed = append(allowed, t)
+	}
+	return allowed, refused
+}
+
+// workflowMoves returns the moves the issue's workflow permits from its
+// current status, before guards, WIP limits, and the acceptance gate.
+func (s *Store) workflowMoves(issue *model.Issue) []Transition {
+	wf := s.WorkflowFor(issue)
+	var out []Transition
+	for _, to := range s.AllStatuses() {
+		if to == issue.Status {
+			continue
+		}
+		if kind, ok := s.transitionKind(wf, issue.Status, to); ok {
+			out = append(out, Transition{From: issue.Status, To: to, Kind: kind})
+		}
+	}
+	return out
+}
+
+func (s *Store) transitionKind(wf Workflow, from, to model.Status) (string, bool) {
+	if from == model.StatusClosed {
+		return TransitionReopen, to == model.StatusOpen
+	}
+	if !s.config.StatusFSM {
+		return TransitionEscape, true
+	}
+	if err := checkTransition(wf, from, to); err != nil {
+		return "", false
+	}
+	if _, ok := wf.ExitRules[from]; ok {
+		return TransitionExit, true
+	}
+	fromIdx, toIdx := indexInSequence(wf.Sequence, from), indexInSequence(wf.Sequence, to)
+	switch {
+	case fromIdx >= 0 && toIdx == fromIdx+1:
+		return TransitionForward, true
+	case fromIdx >= 0 && toIdx >= 0:
+		return TransitionBack, true
+	default:
+		return TransitionEscape, true
+	}
+}
@@ -0,0 +1,246 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/RamXX/nd/internal/model"
+)
+
+func setupWorkflowStore(t *testing.T, dir string) *Store {
+	t.Helper()
+	s := setupFSMStore(t, dir)
+	if err := s.SetConfigValue("status.custom", "delivered,accepted,rejected,verify,proposed"); err != nil {
+		t.Fatalf("set custom: %v", err)
+	}
+	for key, value := range map[string]string{
+		"workflow.bug.sequence":            "open,in_progress,verify,closed",
+		"workflow.epic.sequence":           "open,closed",
+		"workflow.decision.sequence":       "proposed,accepted",
+		"workflow.decision.exit_rules":     "accepted:closed",
+		"workflow.label:hotfix.sequence":   "open,closed",
+		"workflow.label:hotfix.exit_rules": "",
+	} {
+		if err := s.SetConfigValue(key, value); err != nil {
+			t.Fatalf("set %s: %v", key, err)
+		}
+	}
+	return s
+}
+
+func TestWorkflowForPicksTypeThenLabel(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+
+	cases := []struct {
+		issue model.Issue
+		want  string
+	}{
+		{model.Issue{Type: "task"}, DefaultWorkflow},
+		{model.Issue{Type: "bug"}, "bug"},
+		{model.Issue{Type: "bug", Labels: []string{"ui", "hotfix"}}, "label:hotfix"},
+		{model.Issue{Type: "decision"}, "decision"},
+	}
+	for _, tc := range cases {
+		if got := s.WorkflowFor(&tc.issue).Name; got != tc.want {
+			t.Errorf("WorkflowFor(%s %v) = %s, want %s", tc.issue.Type, tc.issue.Labels, got, tc.want)
+		}
+	}
+
+	wf, _ := s.Workflow("decision")
+	if len(wf.ExitRules[model.StatusBlocked]) != 0 {
+		t.Errorf("decision sets its own exit rules and should not inherit blocked: %v", wf.ExitRules)
+	}
+	wf, _ = s.Workflow("bug")
+	if len(wf.ExitRules[model.StatusBlocked]) == 0 {
+		t.Error("bug workflow without exit rules should inherit the vault-wide rules")
+	}
+}
+
+func TestWorkflowTransitionsPerType(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+
+	bug, _ := s.CreateIssue("Bug", "", "bug", 1, "", nil, "")
+	_ = s.UpdateStatus(bug.ID, "in_progress")
+	err := s.CloseIssue(bug.ID, "fixed")
+	if err == nil || !strings.Contains(err.Error(), "bug workflow") || !strings.Contains(err.Error(), "verify") {
+		t.Errorf("bug should not skip verify, got %v", err)
+	}
+	if err := s.UpdateStatus(bug.ID, "verify"); err != nil {
+		t.Fatalf("in_progress -> verify: %v", err)
+	}
+	if err := s.CloseIssue(bug.ID, "fixed"); err != nil {
+		t.Errorf("verify -> closed: %v", err)
+	}
+
+	// Epics go straight from open to closed.
+	epic, _ := s.CreateIssue("Epic", "", "epic", 1, "", nil, "")
+	if err := s.CloseIssue(epic.ID, "done"); err != nil {
+		t.Errorf("epic open -> closed: %v", err)
+	}
+
+	// Tasks still follow the default sequence.
+	task, _ := s.CreateIssue("Task", "", "task", 2, "", nil, "")
+	if err := s.CloseIssue(task.ID, "done"); err == nil {
+		t.Error("task open -> closed should fail under the default sequence")
+	}
+}
+
+func TestWorkflowUnDeferResumesAtFirstStep(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+
+	d, _ := s.CreateIssue("Pick a DB", "", "decision", 2, "", nil, "")
+	if err := s.DeferIssue(d.ID, ""); err != nil {
+		t.Fatalf("DeferIssue: %v", err)
+	}
+	if err := s.UnDeferIssue(d.ID); err != nil {
+		t.Fatalf("UnDeferIssue: %v", err)
+	}
+	read, _ := s.ReadIssue(d.ID)
+	if read.Status != "proposed" {
+		t.Errorf("undefer should resume at proposed, got %s", read.Status)
+	}
+}
+
+func TestWorkflowNewIssueStartsAtFirstStep(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+
+	d, err := s.CreateIssue("Pick a DB", "", "decision", 2, "", nil, "")
+	if err != nil {
+		t.Fatalf("CreateIssue: %v", err)
+	}
+	// Starting at open, which is off-sequence here, would let it jump
+	// straight to accepted.
+	if d.Status != "proposed" {
+		t.Fatalf("decision should start at proposed, got %s", d.Status)
+	}
+	if err := s.UpdateStatus(d.ID, "accepted"); err != nil {
+		t.Fatalf("proposed -> accepted: %v", err)
+	}
+
+	task, _ := s.CreateIssue("Task", "", "task", 2, "", nil, "")
+	if task.Status != model.StatusOpen {
+		t.Errorf("task should start open, got %s", task.Status)
+	}
+}
+
+func TestWorkflowConfigValidation(t *testing.T) {
+	dir := t.TempDir()
+	s := setupWorkflowStore(t, dir)
+
+	for key, value := range map[string]string{
+		"workflow.bogus.sequence":  "open,closed",
+		"workflow.bug.sequence":    "open,nowhere",
+		"workflow.bug.exit_rules":  "verify",
+		"workflow.bug.transitions": "open",
+		"workflow.label:.sequence": "open,closed",
+	} {
+		if err := s.SetConfigValue(key, value); err == nil {
+			t.Errorf("%s=%q should be rejected", key, value)
+		}
+	}
+
+	if got, _ := s.GetConfigValue("workflow.bug.sequence"); got != "open,in_progress,verify,closed" {
+		t.Errorf("workflow.bug.sequence = %q", got)
+	}
+	if errs := s.ValidateWorkflows(); len(errs) != 0 {
+		t.Errorf("ValidateWorkflows: %v", errs)
+	}
+
+	// Dropping a custom status a workflow uses is reported per workflow.
+	if err := s.SetConfigValue("status.custom", "delivered,accepted,rejected,proposed"); err != nil {
+		t.Fatal(err)
+	}
+	errs := s.ValidateWorkflows()
+	if len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "bug:") {
+		t.Errorf("expected one bug workflow problem, got %v", errs)
+	}
+
+	// Clearing every field removes the workflow.
+	_ = s.SetConfigValue("workflow.epic.sequence", "")
+	for _, name := range s.WorkflowNames() {
+		if name == "epic" {
+			t.Error("epic workflow should be removed once empty")
+		}
+	}
+}
+
+func TestAllowedTransitionsMatchesFSM(t *testing.T) {
+	dir := t.TempDir()
+	s := setupFSMStore(t, dir)
+
+	issue, _ := s.CreateIssue("Test", "", "task", 2, "", nil, "")
+	_ = s.UpdateStatus(issue.ID, "in_progress")
+	read, _ := s.ReadIssue(issue.ID)
+
+	kinds := make(map[model.Status]string)
+	allowed, refused := s.AllowedTransitions(read)
+	for _, tr := range allowed {
+		kinds[tr.To] = tr.Kind
+	}
+	if len(refused) != 0 {
+		t.Errorf("no guards are set, but refused = %v", refused)
+	}
+	want := map[model.Status]string{
+		"open":      TransitionBack,
+		"delivered": TransitionForward,
+		"blocked":   TransitionEscape,
+		"deferred":  TransitionEscape,
+		"rejected":  TransitionEscape,
+	}
+	if len(kinds) != len(want) {
+		t.Errorf("AllowedTransitions = %v, want %v", kinds, want)
+	}
+	for st, kind := range want {
+		if kinds[st] != kind {
+			t.Errorf("%s: kind %q, want %q", st, kinds[st], kind)
+		}
+	}
+
+	// Every listed move must be accepted by UpdateStatus, every other one refused.
+	for _, to := range s.AllStatuses() {
+		if to == read.Status || to == model.StatusClosed {
+			continue
+		}
+		probe, _ := s.CreateIssue("Probe", "", "task", 2, "", nil, "")
+		_ = s.UpdateStatus(probe.ID, "in_progress")
+		err := s.UpdateStatus(probe.ID, to)
+		if _, listed := kinds[to]; listed != (err == nil) {
+			t.Errorf("in_progress -> %s: listed=%v but UpdateStatus err=%v", to, listed, err)
+		}
+	}
+
+	for _, tr := range s.WorkflowTransitions(s.WorkflowFor(read)) {
+		if tr.From == model.StatusClosed && tr.To != model.StatusOpen {
+			t.Errorf("closed may only reopen, got %s", tr.To)
+		}
+		if tr.From == model.StatusBlocked && tr.Kind != TransitionExit {
+			t.Errorf("blocked has exit rules, got kind %q to %s", tr.Kind, tr.To)
+		}
+	}
+}
+
+func TestAllowedTransitionsAppliesGuards(t *testing.T) {
+	dir := t.TempDir()
+	s := setupFSMStore(t, dir)
+	if err := s.SetConfigValue("status.guards", "in_progress:assignee"); err != nil {
+		t.Fatalf("set guards: %v", err)
+	}
+
+	issue, _ := s.CreateIssue("Test", "", "task", 2, "", nil, "")
+	allowed, refused := s.AllowedTransitions(issue)
+	for _, tr := range allowed {
+		if tr.To == model.StatusInProgress {
+			t.Error("in_progress is guarded by assignee and should not be allowed")
+		}
+	}
+	if len(refused) != 1 || refused[0].To != model.StatusInProgress || !strings.Contains(refused[0].Refused, "no assignee") {
+		t.Errorf("refused = %+v, want in_progress with the guard message", refused)
+	}
+	if err := s.UpdateStatus(issue.ID, "in_progress"); err == nil {
+		t.Error("UpdateStatus should refuse the guarded move too")
+	}
+}
@@ -174,6 +174,16 @@ func RenderAccent(s string) string {
 	return AccentStyle.Render(s)
 }
 
+// RenderOverdue renders a past-due marker in the alarm color.
+func RenderOverdue(s string) string {
+	return StatusBlockedStyle.Bold(true).Render(s)
+}
+
+// RenderDueSoon renders an approaching-deadline marker in the warning color.
+func RenderDueSoon(s string) string {
+	return StatusInProgressStyle.Render(s)
+}
+
 // RenderClosedLine renders an entire line in the closed/dimmed style.
 func RenderClosedLine(line string) string {
 	return StatusClosedStyle.Render(line)